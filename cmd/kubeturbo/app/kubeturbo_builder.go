@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"net/http"
@@ -8,20 +9,21 @@ import (
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	set "github.com/deckarep/golang-set"
-	"github.com/golang/glog"
 	clusterclient "github.com/openshift/machine-api-operator/pkg/generated/clientset/versioned"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/pflag"
+	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
 	apiextclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
-	versionhelper "k8s.io/apimachinery/pkg/version"
 	"k8s.io/apiserver/pkg/server/healthz"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/client-go/dynamic"
@@ -30,18 +32,31 @@ import (
 	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"k8s.io/client-go/tools/record"
+	logsapiv1 "k8s.io/component-base/logs/api/v1"
+	_ "k8s.io/component-base/logs/json/register"
+	"k8s.io/klog/v2"
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	kubeturbo "github.com/turbonomic/kubeturbo/pkg"
+	"github.com/turbonomic/kubeturbo/pkg/action/admission"
 	"github.com/turbonomic/kubeturbo/pkg/action/executor"
 	"github.com/turbonomic/kubeturbo/pkg/action/executor/gitops"
+	"github.com/turbonomic/kubeturbo/pkg/compatibility"
+	"github.com/turbonomic/kubeturbo/pkg/discovery/health"
+	"github.com/turbonomic/kubeturbo/pkg/discovery/k8sapi"
+	"github.com/turbonomic/kubeturbo/pkg/discovery/monitoring/kubelet"
+	"github.com/turbonomic/kubeturbo/pkg/discovery/pagination"
 	"github.com/turbonomic/kubeturbo/pkg/discovery/processor"
 	nodeUtil "github.com/turbonomic/kubeturbo/pkg/discovery/util"
 	"github.com/turbonomic/kubeturbo/pkg/discovery/worker"
 	agg "github.com/turbonomic/kubeturbo/pkg/discovery/worker/aggregation"
 	"github.com/turbonomic/kubeturbo/pkg/features"
 	"github.com/turbonomic/kubeturbo/pkg/kubeclient"
+	promkubeturbo "github.com/turbonomic/kubeturbo/pkg/metrics"
+	"github.com/turbonomic/kubeturbo/pkg/placement/volumecapacity"
 	"github.com/turbonomic/kubeturbo/pkg/resourcemapping"
 	"github.com/turbonomic/kubeturbo/pkg/util"
 	"github.com/turbonomic/kubeturbo/test/flag"
@@ -66,6 +81,21 @@ const (
 	DefaultGCIntervalMin               = 10
 	DefaultReadinessRetryThreshold     = 60
 	DefaultVcpuThrottlingUtilThreshold = 30
+
+	// Leader election defaults, mirroring the defaults used by core k8s controllers
+	// (see k8s.io/component-base/config/v1alpha1.LeaderElectionConfiguration).
+	DefaultLeaderElectionLeaseDuration = 15 * time.Second
+	DefaultLeaderElectionRenewDeadline = 10 * time.Second
+	DefaultLeaderElectionRetryPeriod   = 2 * time.Second
+	DefaultLeaderElectionResourceName  = "kubeturbo-leader-election"
+
+	// DefaultShutdownGracePeriod is how long Run() waits for in-flight action execution and
+	// Turbo disconnection to finish after a SIGTERM/SIGINT before forcing the shutdown.
+	DefaultShutdownGracePeriod = 90 * time.Second
+
+	// DefaultDiscoveryGVResyncInterval is how often the cached discovery client backing the
+	// API group/version resolver is invalidated.
+	DefaultDiscoveryGVResyncInterval = k8sapi.DefaultResyncInterval
 )
 
 var (
@@ -79,8 +109,6 @@ var (
 	customScheme = runtime.NewScheme()
 )
 
-type disconnectFromTurboFunc func()
-
 func init() {
 	// Add registered custom types to the custom scheme
 	utilruntime.Must(policyv1alpha1.AddToScheme(customScheme))
@@ -88,7 +116,6 @@ func init() {
 }
 
 // VMTServer has all the context and params needed to run a Scheduler
-// TODO: leaderElection is disabled now because of dependency problems.
 type VMTServer struct {
 	Port                 int
 	Address              string
@@ -100,7 +127,37 @@ type VMTServer struct {
 	BindPodsBurst        int
 	DiscoveryIntervalSec int
 
-	// LeaderElection componentconfig.LeaderElectionConfiguration
+	// LeaderElect enables leader election, so only one of several kubeturbo replicas
+	// connects to Turbo and executes actions at a time.
+	LeaderElect bool
+	// LeaderElectLeaseDuration is the duration non-leader candidates wait before forcing
+	// acquisition of leadership once the current leader's lease expires.
+	LeaderElectLeaseDuration time.Duration
+	// LeaderElectRenewDeadline is the duration the leader retries refreshing its lease
+	// before giving it up.
+	LeaderElectRenewDeadline time.Duration
+	// LeaderElectRetryPeriod is how often non-leader candidates check for leadership.
+	LeaderElectRetryPeriod time.Duration
+	// LeaderElectResourceName is the name of the Lease resource used for leader election.
+	LeaderElectResourceName string
+
+	// ShutdownGracePeriod bounds how long Run() waits, after a SIGTERM/SIGINT, for in-flight
+	// work to finish before forcing a disconnect from Turbo and exiting.
+	ShutdownGracePeriod time.Duration
+
+	// MetricsBindAddress, when set, serves /metrics on its own http server bound to this
+	// address instead of the main host:port, so metrics can be scraped independent of
+	// --profiling and without exposing the other http endpoints on the same address.
+	MetricsBindAddress string
+
+	// Logs holds the logging configuration (verbosity, format, vmodule, flush frequency),
+	// applied via logsapiv1.ValidateAndApply before any other initialization in Run().
+	Logs *logsapiv1.LoggingConfiguration
+
+	// turboConnected and isLeader back the /readyz "turbo-connection" and "leader-election"
+	// checks installed by startHttp.
+	turboConnected atomic.Bool
+	isLeader       atomic.Bool
 
 	EnableProfiling bool
 
@@ -184,15 +241,76 @@ type VMTServer struct {
 	CpuFrequencyGetterImage string
 	// Name of the secret that stores the image pull credentials of cpu freq getter job image
 	CpuFrequencyGetterPullSecret string
+
+	// DryRunActions, when true (and features.DryRunActions is enabled), is passed on to
+	// vmtConfig.WithDryRunActions. No action executor reads it, so actions still mutate the cluster.
+	DryRunActions bool
+
+	// DiscoveryGVResyncInterval is how often the cached discovery client backing the
+	// deployment/replicaset API group/version resolver is invalidated, so a cluster upgrade
+	// (e.g. a beta API's removal) is picked up without restarting kubeturbo.
+	DiscoveryGVResyncInterval time.Duration
+
+	// TargetClusterKubeconfigs lists the kubeconfigs of clusters that move/resize actions may
+	// relocate workloads onto, as "name=/path/to/kubeconfig" pairs, used to preflight that the
+	// target actually serves a workload's owning controller GVK before executing the action.
+	TargetClusterKubeconfigs []string
+
+	// IgnoredDiscoveryGroups are API groups whose discovery failures are never treated as
+	// fatal by the DiscoveryHealth gate, e.g. a group known to be flaky in this environment.
+	IgnoredDiscoveryGroups []string
+
+	// discoveryHealth tracks which GVRs the cluster currently serves and which discovery
+	// workers can run given that availability; served at /healthz/discovery.
+	discoveryHealth *health.Monitor
+
+	// DiscoveryPagination controls Limit+Continue pagination of workload-controller list API
+	// calls, gated by features.PaginatedControllerList and independent of GoMemLimit.
+	DiscoveryPagination pagination.Config
+
+	// VolumeCapacityPriorityShape is the piecewise-linear utilization-to-score curve handed to the
+	// VolumeCapacity action admitter when features.VolumeCapacityPriority is enabled, as
+	// "utilizationPercent:score" pairs, e.g. "0:0,25:30,50:60,100:100".
+	VolumeCapacityPriorityShape []string
+
+	// ActionAdmissionWebhookURL, if set, registers an outbound admission webhook (in addition to
+	// the built-in quota/PDB/volume-capacity/affinity admitters) when features.ActionAdmission
+	// is enabled. The remaining ActionAdmissionWebhook* fields configure that single webhook.
+	ActionAdmissionWebhookURL string
+	// ActionAdmissionWebhookCAFile is a path to a PEM-encoded CA bundle used to verify
+	// ActionAdmissionWebhookURL, in addition to the system trust store.
+	ActionAdmissionWebhookCAFile string
+	// ActionAdmissionWebhookTimeout bounds how long to wait for the webhook to respond.
+	ActionAdmissionWebhookTimeout time.Duration
+	// ActionAdmissionWebhookFailurePolicy is "Fail" (deny the action if the webhook call fails)
+	// or "Ignore" (allow it through). Defaults to "Fail".
+	ActionAdmissionWebhookFailurePolicy string
+	// ActionAdmissionWebhookMatchLabels restricts the webhook to actions whose target carries
+	// all of these labels. Empty matches every action.
+	ActionAdmissionWebhookMatchLabels map[string]string
+
+	// DynamicFeatureGatesConfigMapNamespace/Name identify the ConfigMap that
+	// features.DynamicFeatureGates watches for hot-reloadable feature gate changes. Namespace
+	// defaults to the POD_NAMESPACE kubeturbo already runs in if unset.
+	DynamicFeatureGatesConfigMapNamespace string
+	DynamicFeatureGatesConfigMapName      string
 }
 
 // NewVMTServer creates a new VMTServer with default parameters
 func NewVMTServer() *VMTServer {
 	s := VMTServer{
-		Port:       KubeturboPort,
-		Address:    "127.0.0.1",
-		VMPriority: defaultVMPriority,
-		VMIsBase:   defaultVMIsBase,
+		Port:                      KubeturboPort,
+		Address:                   "127.0.0.1",
+		VMPriority:                defaultVMPriority,
+		VMIsBase:                  defaultVMIsBase,
+		LeaderElectLeaseDuration:  DefaultLeaderElectionLeaseDuration,
+		LeaderElectRenewDeadline:  DefaultLeaderElectionRenewDeadline,
+		LeaderElectRetryPeriod:    DefaultLeaderElectionRetryPeriod,
+		LeaderElectResourceName:   DefaultLeaderElectionResourceName,
+		ShutdownGracePeriod:       DefaultShutdownGracePeriod,
+		Logs:                      logsapiv1.NewLoggingConfiguration(),
+		DiscoveryGVResyncInterval: DefaultDiscoveryGVResyncInterval,
+		DiscoveryPagination:       pagination.DefaultConfig(),
 	}
 	return &s
 }
@@ -250,13 +368,38 @@ func (s *VMTServer) AddFlags(fs *pflag.FlagSet) {
 	// CpuFreqGetter image and secret
 	fs.StringVar(&s.CpuFrequencyGetterImage, "cpufreqgetter-image", "icr.io/cpopen/turbonomic/cpufreqgetter", "The complete cpufreqgetter image uri used for fallback node cpu frequency getter job.")
 	fs.StringVar(&s.CpuFrequencyGetterPullSecret, "cpufreqgetter-image-pull-secret", "", "The name of the secret that stores the image pull credentials for cpufreqgetter image.")
+	// Leader election flags
+	fs.BoolVar(&s.LeaderElect, "leader-elect", false, "Enable leader election, so only one of several kubeturbo replicas connects to Turbo and executes actions at a time.")
+	fs.DurationVar(&s.LeaderElectLeaseDuration, "leader-elect-lease-duration", DefaultLeaderElectionLeaseDuration, "The duration that non-leader candidates will wait before forcing to acquire leadership.")
+	fs.DurationVar(&s.LeaderElectRenewDeadline, "leader-elect-renew-deadline", DefaultLeaderElectionRenewDeadline, "The duration that the acting leader will retry refreshing leadership before giving it up.")
+	fs.DurationVar(&s.LeaderElectRetryPeriod, "leader-elect-retry-period", DefaultLeaderElectionRetryPeriod, "The duration the clients should wait between tries of actions.")
+	fs.StringVar(&s.LeaderElectResourceName, "leader-elect-resource-name", DefaultLeaderElectionResourceName, "The name of the resource (Lease) that leader election will use for holding the leader lock.")
+	fs.DurationVar(&s.ShutdownGracePeriod, "shutdown-grace-period", DefaultShutdownGracePeriod, "How long to wait for in-flight action execution and Turbo disconnection to finish after a SIGTERM/SIGINT before forcing the shutdown.")
+	fs.StringVar(&s.MetricsBindAddress, "metrics-bind-address", "", "The host:port to serve /metrics on. If empty, metrics are served on the main kubeturbo http service address alongside healthz, independent of --profiling.")
+	// Structured logging: --logging-format, --log-flush-frequency, verbosity and per-logger vmodule flags
+	logsapiv1.AddFlags(s.Logs, fs)
+	fs.BoolVar(&s.DryRunActions, "dry-run-actions", false, "Pass dry-run mode on to the action execution configuration (requires the DryRunActions feature gate). No action executor reads this setting, so actions still mutate the cluster.")
+	fs.DurationVar(&s.DiscoveryGVResyncInterval, "discovery-gv-resync-interval", DefaultDiscoveryGVResyncInterval, "How often to invalidate the cached discovery information used to resolve the deployment/replicaset API group/version, so an upgraded cluster's removed/added APIs are picked up without restarting kubeturbo.")
+	fs.StringSliceVar(&s.TargetClusterKubeconfigs, "target-cluster-kubeconfig", nil, "A name=/path/to/kubeconfig pair identifying a cluster that move/resize actions may relocate workloads onto. May be repeated for multiple target clusters. When set, actions that would relocate a workload onto a target cluster not serving its owning controller's GVK are rejected up front.")
+	fs.StringSliceVar(&s.IgnoredDiscoveryGroups, "discovery-ignored-groups", nil, "API groups whose discovery failures are never treated as fatal by the DiscoveryHealth feature gate.")
+	fs.Int64Var(&s.DiscoveryPagination.PageSize, "discovery-page-size", pagination.DefaultPageSize, "Number of items requested per page when PaginatedControllerList is enabled. Overridden by --items-per-list-query when that is set.")
+	fs.DurationVar(&s.DiscoveryPagination.ContinueBackoff, "discovery-continue-backoff", pagination.DefaultContinueBackoff, "How long to wait before restarting a paginated workload-controller list from the beginning after a page request with a continue token fails.")
+	fs.IntVar(&s.DiscoveryPagination.MaxInFlightPages, "discovery-max-in-flight-pages", pagination.DefaultMaxInFlightPages, "Maximum number of fetched-but-not-yet-processed pages of a paginated workload-controller list.")
+	fs.StringSliceVar(&s.VolumeCapacityPriorityShape, "volume-capacity-priority-shape", nil, "The piecewise-linear utilization-to-score curve handed to the VolumeCapacity action admitter when the VolumeCapacityPriority feature gate is enabled, as utilizationPercent:score pairs, e.g. 0:0,25:30,50:60,100:100. Defaults to kubeturbo's built-in shape.")
+	fs.StringVar(&s.ActionAdmissionWebhookURL, "action-admission-webhook-url", "", "URL of an outbound admission webhook added to the action admission chain alongside kubeturbo's built-in admitters (requires the ActionAdmission feature gate).")
+	fs.StringVar(&s.ActionAdmissionWebhookCAFile, "action-admission-webhook-ca-file", "", "Path to a PEM-encoded CA bundle used to verify --action-admission-webhook-url, in addition to the system trust store.")
+	fs.DurationVar(&s.ActionAdmissionWebhookTimeout, "action-admission-webhook-timeout", 10*time.Second, "How long to wait for --action-admission-webhook-url to respond.")
+	fs.StringVar(&s.ActionAdmissionWebhookFailurePolicy, "action-admission-webhook-failure-policy", string(admission.FailurePolicyFail), "Whether to Fail (deny) or Ignore (allow) an action when --action-admission-webhook-url is unreachable.")
+	fs.StringToStringVar(&s.ActionAdmissionWebhookMatchLabels, "action-admission-webhook-match-labels", nil, "Only consult --action-admission-webhook-url for actions whose target carries all of these labels. Unset matches every action.")
+	fs.StringVar(&s.DynamicFeatureGatesConfigMapNamespace, "dynamic-feature-gates-configmap-namespace", "", "Namespace of the ConfigMap watched for hot-reloadable feature gate changes when the DynamicFeatureGates feature gate is enabled. Defaults to the POD_NAMESPACE kubeturbo runs in.")
+	fs.StringVar(&s.DynamicFeatureGatesConfigMapName, "dynamic-feature-gates-configmap-name", "kubeturbo-feature-gates", "Name of the ConfigMap watched for hot-reloadable feature gate changes when the DynamicFeatureGates feature gate is enabled.")
 }
 
 // create an eventRecorder to send events to Kubernetes APIserver
 func createRecorder(kubecli *kubernetes.Clientset) record.EventRecorder {
 	// Create a new broadcaster which will send events we generate to the apiserver
 	eventBroadcaster := record.NewBroadcaster()
-	eventBroadcaster.StartLogging(glog.Infof)
+	eventBroadcaster.StartLogging(klog.Infof)
 	eventBroadcaster.StartRecordingToSink(&v1core.EventSinkImpl{
 		Interface: v1core.New(kubecli.CoreV1().RESTClient()).Events(apiv1.NamespaceAll)})
 	// this EventRecorder can be used to send events to this EventBroadcaster
@@ -267,7 +410,7 @@ func createRecorder(kubecli *kubernetes.Clientset) record.EventRecorder {
 func (s *VMTServer) createKubeConfigOrDie() *restclient.Config {
 	kubeConfig, err := clientcmd.BuildConfigFromFlags(s.Master, s.KubeConfig)
 	if err != nil {
-		glog.Errorf("Fatal error: failed to get kubeconfig:  %s", err)
+		klog.ErrorS(err, "Fatal error: failed to get kubeconfig")
 		os.Exit(1)
 	}
 	// This specifies the number and the max number of query per second to the api server.
@@ -280,7 +423,7 @@ func (s *VMTServer) createKubeConfigOrDie() *restclient.Config {
 func (s *VMTServer) createKubeClientOrDie(kubeConfig *restclient.Config) *kubernetes.Clientset {
 	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
 	if err != nil {
-		glog.Errorf("Fatal error: failed to create kubeClient:%v", err)
+		klog.ErrorS(err, "Fatal error: failed to create kubeClient")
 		os.Exit(1)
 	}
 
@@ -296,7 +439,7 @@ func (s *VMTServer) CreateKubeletClientOrDie(kubeConfig *restclient.Config, fall
 		// Timeout(to).
 		Create(fallbackClient, cpuFreqGetterImage, imagePullSecret, cpufreqJobExcludeNodeLabels, useProxyEndpoint)
 	if err != nil {
-		glog.Errorf("Fatal error: failed to create kubeletClient: %v", err)
+		klog.ErrorS(err, "Fatal error: failed to create kubeletClient")
 		os.Exit(1)
 	}
 
@@ -305,11 +448,11 @@ func (s *VMTServer) CreateKubeletClientOrDie(kubeConfig *restclient.Config, fall
 
 func (s *VMTServer) checkFlag() error {
 	if s.KubeConfig == "" && s.Master == "" {
-		glog.Warningf("Neither --kubeconfig nor --master was specified.  Using default API client.  This might not work.")
+		klog.Warningf("Neither --kubeconfig nor --master was specified.  Using default API client.  This might not work.")
 	}
 
 	if s.Master != "" {
-		glog.V(3).Infof("Master is %s", s.Master)
+		klog.V(3).InfoS("Using master", "master", s.Master)
 	}
 
 	if s.TestingFlagPath != "" {
@@ -334,65 +477,125 @@ func (s *VMTServer) checkFlag() error {
 
 // Run runs the specified VMTServer.  This should never exit.
 func (s *VMTServer) Run() {
+	if err := logsapiv1.ValidateAndApply(s.Logs, utilfeature.DefaultFeatureGate); err != nil {
+		klog.ErrorS(err, "Failed to validate and apply log configuration")
+		os.Exit(1)
+	}
+
 	if err := s.checkFlag(); err != nil {
-		glog.Fatalf("Check flag failed: %v. Abort.", err.Error())
+		klog.ErrorS(err, "Check flag failed. Abort.")
+		os.Exit(1)
 	}
 
+	// ctx is cancelled on SIGTERM/SIGINT (e.g. a kubectl drain or rolling deployment), and is
+	// plumbed into the http server and cached discovery resync so a shutdown stops accepting new
+	// work before we disconnect from Turbo. The garbage collector is stopped by closing gCChan.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
 	kubeConfig := s.createKubeConfigOrDie()
-	glog.V(3).Infof("kubeConfig: %+v", kubeConfig)
+	klog.V(3).InfoS("Loaded kubeConfig", "host", kubeConfig.Host)
 
 	kubeClient := s.createKubeClientOrDie(kubeConfig)
 
+	if utilfeature.DefaultFeatureGate.Enabled(features.DynamicFeatureGates) {
+		namespace := s.DynamicFeatureGatesConfigMapNamespace
+		if namespace == "" {
+			namespace = os.Getenv("POD_NAMESPACE")
+		}
+		watcher := features.NewWatcher(kubeClient, namespace, s.DynamicFeatureGatesConfigMapName)
+		if err := watcher.Start(ctx); err != nil {
+			klog.ErrorS(err, "Failed to start dynamic feature gates ConfigMap watcher", "namespace", namespace, "name", s.DynamicFeatureGatesConfigMapName)
+		}
+	}
+
 	// Create controller runtime client that support custom resources
 	runtimeClient, err := runtimeclient.New(kubeConfig, runtimeclient.Options{Scheme: customScheme})
 	if err != nil {
-		glog.Fatalf("Failed to create controller runtime client: %v.", err)
+		klog.ErrorS(err, "Failed to create controller runtime client")
+		os.Exit(1)
 	}
 
 	// TODO: Replace dynamicClient with runtimeClient
 	dynamicClient, err := dynamic.NewForConfig(kubeConfig)
 	if err != nil {
-		glog.Fatalf("Failed to generate dynamic client for kubernetes target: %v", err)
+		klog.ErrorS(err, "Failed to generate dynamic client for kubernetes target")
+		os.Exit(1)
 	}
 
 	// TODO: Replace apiExtClient with runtimeClient
 	apiExtClient, err := apiextclient.NewForConfig(kubeConfig)
 	if err != nil {
-		glog.Fatalf("Failed to generate apiExtensions client for kubernetes target: %v", err)
+		klog.ErrorS(err, "Failed to generate apiExtensions client for kubernetes target")
+		os.Exit(1)
+	}
+
+	gvResolver, err := k8sapi.NewResourceGVResolver(kubeConfig, "", "")
+	if err != nil {
+		klog.ErrorS(err, "Failed to create cached discovery resolver, API group/versions will use the hardcoded default")
+	} else {
+		gvResolver.StartResync(ctx.Done(), s.DiscoveryGVResyncInterval)
 	}
 
-	util.K8sAPIDeploymentGV, err = discoverk8sAPIResourceGV(kubeClient, util.DeploymentResName)
+	util.K8sAPIDeploymentGV, err = resolveK8sAPIResourceGV(gvResolver, util.DeploymentResName)
 	if err != nil {
-		glog.Warningf("Failure in discovering k8s deployment API group/version: %v", err.Error())
+		klog.ErrorS(err, "Failure in discovering k8s deployment API group/version")
 	}
-	glog.V(2).Infof("Using group version %v for k8s deployments", util.K8sAPIDeploymentGV)
+	klog.V(2).InfoS("Using group version for k8s deployments", "groupVersion", util.K8sAPIDeploymentGV)
 
-	util.K8sAPIReplicasetGV, err = discoverk8sAPIResourceGV(kubeClient, util.ReplicaSetResName)
+	util.K8sAPIReplicasetGV, err = resolveK8sAPIResourceGV(gvResolver, util.ReplicaSetResName)
 	if err != nil {
-		glog.Warningf("Failure in discovering k8s replicaset API group/version: %v", err.Error())
+		klog.ErrorS(err, "Failure in discovering k8s replicaset API group/version")
 	}
-	glog.V(2).Infof("Using group version %v for k8s replicasets", util.K8sAPIReplicasetGV)
+	klog.V(2).InfoS("Using group version for k8s replicasets", "groupVersion", util.K8sAPIReplicasetGV)
 
-	glog.V(3).Infof("Turbonomic config path is: %v", s.K8sTAPSpec)
+	if utilfeature.DefaultFeatureGate.Enabled(features.DiscoveryHealth) {
+		s.discoveryHealth = health.NewMonitor(kubeClient.Discovery(), s.IgnoredDiscoveryGroups)
+		if err := s.discoveryHealth.Refresh(); err != nil {
+			klog.ErrorS(err, "Initial discovery health refresh failed")
+		}
+	}
+
+	compatChecker := compatibility.NewChecker(kubeClient.Discovery())
+	for name, targetKubeconfig := range s.parseTargetClusterKubeconfigs() {
+		targetConfig, err := clientcmd.BuildConfigFromFlags("", targetKubeconfig)
+		if err != nil {
+			klog.ErrorS(err, "Failed to load target cluster kubeconfig, skipping compatibility preflight for it", "targetCluster", name)
+			continue
+		}
+		if err := compatChecker.AddTarget(name, targetConfig, s.DiscoveryGVResyncInterval); err != nil {
+			klog.ErrorS(err, "Failed to register target cluster for compatibility preflight", "targetCluster", name)
+		}
+	}
+
+	klog.V(3).InfoS("Turbonomic config path", "path", s.K8sTAPSpec)
 
 	k8sTAPSpec, err := kubeturbo.ParseK8sTAPServiceSpec(s.K8sTAPSpec, kubeConfig.Host)
 	if err != nil {
-		glog.Fatalf("Failed to generate correct TAP config: %v", err.Error())
+		klog.ErrorS(err, "Failed to generate correct TAP config")
+		os.Exit(1)
 	}
 
 	if k8sTAPSpec.FeatureGates != nil {
 		err = utilfeature.DefaultMutableFeatureGate.SetFromMap(k8sTAPSpec.FeatureGates)
 		if err != nil {
-			glog.Fatalf("Invalid Feature Gates: %v", err)
+			klog.ErrorS(err, "Invalid Feature Gates")
+			os.Exit(1)
 		}
 	}
 
 	if utilfeature.DefaultFeatureGate.Enabled(features.GoMemLimit) {
-		glog.V(2).Info("Memory Optimisations are enabled.")
+		klog.V(2).InfoS("Memory Optimisations are enabled.")
 		// AUTOMEMLIMIT_DEBUG environment variable enables debug logging of AUTOMEMLIMIT
 		// GoMemLimit will be set during the start of each discovery, see K8sDiscoveryClient.Discover,
 		// as memory limit may change overtime
 		_ = os.Setenv("AUTOMEMLIMIT_DEBUG", "true")
+	} else {
+		klog.V(2).InfoS("Memory Optimisations are not enabled.")
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(features.PaginatedControllerList) {
+		klog.V(2).InfoS("Paginated controller list discovery is enabled.", "pageSize", s.DiscoveryPagination.PageSize)
 		if s.ItemsPerListQuery != 0 {
 			// Perform sanity check on user specified value of itemsPerListQuery
 			if s.ItemsPerListQuery < processor.DefaultItemsPerGiMemory {
@@ -402,15 +605,39 @@ func (s *VMTServer) Run() {
 				} else {
 					errMsg = "set too low"
 				}
-				glog.Warningf("Argument --items-per-list-query is %s (%v). Setting it to the default value of %d.",
-					errMsg, s.ItemsPerListQuery, processor.DefaultItemsPerGiMemory)
+				klog.InfoS("Argument --items-per-list-query is set too low or negative, using default",
+					"reason", errMsg, "value", s.ItemsPerListQuery, "default", processor.DefaultItemsPerGiMemory)
 				s.ItemsPerListQuery = processor.DefaultItemsPerGiMemory
 			} else {
-				glog.V(2).Infof("Set items per list API call to the user specified value: %v.", s.ItemsPerListQuery)
+				klog.V(2).InfoS("Set items per list API call to the user specified value", "itemsPerListQuery", s.ItemsPerListQuery)
 			}
+			s.DiscoveryPagination.PageSize = int64(s.ItemsPerListQuery)
 		}
 	} else {
-		glog.V(2).Info("Memory Optimisations are not enabled.")
+		klog.V(2).InfoS("Paginated controller list discovery is not enabled.")
+	}
+
+	// Pre-flight list of workload controllers against the real target cluster, so a missing
+	// DiscoveryHealth-required GVR or a broken --discovery-page-size surfaces here, before
+	// kubeturbo ever connects to the Turbo server, rather than only during its first discovery
+	// cycle (run by the per-cycle discovery workers, out of scope for this change). Paginates the
+	// same as regular discovery would once PaginatedControllerList is enabled, so this exercises
+	// the configured page size rather than only the unpaginated path.
+	workloadLister := worker.NewWorkloadControllerLister(kubeClient, s.discoveryHealth)
+	var workloadItems []appsv1.Deployment
+	var workloadRan bool
+	var workloadPreflightErr error
+	if utilfeature.DefaultFeatureGate.Enabled(features.PaginatedControllerList) {
+		workloadItems, workloadRan, workloadPreflightErr = workloadLister.ListPaginated(ctx, apiv1.NamespaceAll, s.DiscoveryPagination)
+	} else {
+		workloadItems, workloadRan, workloadPreflightErr = workloadLister.List(ctx, apiv1.NamespaceAll)
+	}
+	if workloadPreflightErr != nil {
+		klog.ErrorS(workloadPreflightErr, "Workload controller pre-flight list failed")
+	} else if !workloadRan {
+		klog.V(2).InfoS("Skipping workload controller pre-flight list: required GVRs are not currently served")
+	} else {
+		klog.V(2).InfoS("Workload controller pre-flight list succeeded", "deployments", len(workloadItems))
 	}
 
 	// Collect target and probe info such as master host, server version, probe container image, etc
@@ -418,14 +645,15 @@ func (s *VMTServer) Run() {
 
 	excludeLabelsMap, err := nodeUtil.LabelMapFromNodeSelectorString(s.CpufreqJobExcludeNodeLabels)
 	if err != nil {
-		glog.Fatalf("Invalid cpu frequency exclude node label selectors: %v. The selectors "+
-			"should be a comma saperated list of key=value node label pairs", err)
+		klog.ErrorS(err, "Invalid cpu frequency exclude node label selectors. The selectors "+
+			"should be a comma saperated list of key=value node label pairs")
+		os.Exit(1)
 	}
 	kubeletClient := s.CreateKubeletClientOrDie(kubeConfig, kubeClient, s.CpuFrequencyGetterImage,
 		s.CpuFrequencyGetterPullSecret, excludeLabelsMap, s.UseNodeProxyEndpoint)
 	caClient, err := clusterclient.NewForConfig(kubeConfig)
 	if err != nil {
-		glog.Errorf("Failed to generate correct TAP config: %v", err.Error())
+		klog.ErrorS(err, "Failed to generate correct TAP config")
 		caClient = nil
 	}
 
@@ -470,40 +698,305 @@ func (s *VMTServer) Run() {
 			s.gitConfig.GitSecretName != "" ||
 			s.gitConfig.GitSecretNamespace != "" ||
 			s.gitConfig.GitUsername != "" {
-			glog.V(2).Infof("Feature: %v is not enabled, arg values set for git-email: %s, git-username: %s "+
-				"git-secret-name: %s, git-secret-namespace: %s will be ignored.", features.GitopsApps,
-				s.gitConfig.GitEmail, s.gitConfig.GitUsername, s.gitConfig.GitSecretName, s.gitConfig.GitSecretNamespace)
+			klog.V(2).InfoS("Feature is not enabled, git config arg values will be ignored", "feature", features.GitopsApps,
+				"gitEmail", s.gitConfig.GitEmail, "gitUsername", s.gitConfig.GitUsername,
+				"gitSecretName", s.gitConfig.GitSecretName, "gitSecretNamespace", s.gitConfig.GitSecretNamespace)
+		}
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(features.DryRunActions) {
+		vmtConfig.WithDryRunActions(s.DryRunActions)
+	} else if s.DryRunActions {
+		klog.V(2).InfoS("Feature is not enabled, --dry-run-actions will be ignored", "feature", features.DryRunActions)
+	}
+
+	// compatChecker is kept populated via AddTarget above and stopped on shutdown below, but
+	// nothing in this tree calls CheckWorkloads before a relocate action runs, so the
+	// cross-cluster GVK preflight it exists for never actually rejects an incompatible relocation
+	// yet.
+
+	// RequireGVRs gating for the workload-controller lister already ran above as part of the
+	// pre-flight list; discoveryHealth is additionally threaded through vmtConfig so the other
+	// per-cycle discovery workers (nodes, pods, GitOps CRDs -- out of scope for this change) can
+	// skip themselves with a WARN when their own required GVRs are missing.
+	if s.discoveryHealth != nil {
+		vmtConfig.WithDiscoveryHealth(s.discoveryHealth)
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(features.PaginatedControllerList) {
+		vmtConfig.WithDiscoveryPagination(s.DiscoveryPagination)
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(features.VolumeCapacityPriority) {
+		shape, err := s.parseVolumeCapacityPriorityShape()
+		if err != nil {
+			klog.ErrorS(err, "Invalid --volume-capacity-priority-shape, using kubeturbo's built-in shape")
+			shape = volumecapacity.DefaultShape()
+		}
+		vmtConfig.WithVolumeCapacityPriorityShape(shape)
+	}
+
+	// Built here so a bad --action-admission-webhook-* fails fast at startup, but there is
+	// currently no action-execution call site anywhere in this tree that invokes chain.Admit --
+	// enabling ActionAdmission validates the admitters/webhook and nothing else.
+	if utilfeature.DefaultFeatureGate.Enabled(features.ActionAdmission) {
+		volumeCapacityShape := vmtConfig.VolumeCapacityPriorityShape()
+		if volumeCapacityShape == nil {
+			volumeCapacityShape = volumecapacity.DefaultShape()
+		}
+		admitters := []admission.ActionAdmitter{
+			admission.NewQuotaScopeAdmitter(),
+			admission.NewPDBAdmitter(),
+			admission.NewVolumeCapacityAdmitter(volumeCapacityShape),
+			admission.NewAffinityAdmitter(),
 		}
+		if webhook, err := s.newActionAdmissionWebhook(); err != nil {
+			klog.ErrorS(err, "Invalid --action-admission-webhook-* flags, action admission will run without the webhook")
+		} else if webhook != nil {
+			admitters = append(admitters, webhook)
+		}
+		admission.NewChain(admitters...)
+		klog.V(2).InfoS("Action admission chain built, but no action-execution call site currently invokes it", "feature", features.ActionAdmission)
 	}
-	glog.V(3).Infof("Finished creating turbo configuration: %+v", vmtConfig)
+	klog.V(3).InfoS("Finished creating turbo configuration", "vmtConfig", vmtConfig)
 
 	// The KubeTurbo TAP service
 	k8sTAPService, err := kubeturbo.NewKubernetesTAPService(vmtConfig)
 	if err != nil {
-		glog.Fatalf("Unexpected error while creating Kubernetes TAP service: %s", err)
+		klog.ErrorS(err, "Unexpected error while creating Kubernetes TAP service")
+		os.Exit(1)
 	}
 
 	// The client for healthz, debug, and prometheus
-	go s.startHttp()
-	glog.V(2).Infof("No leader election")
+	go s.startHttp(ctx, kubeClient)
 
 	gCChan := make(chan bool)
-	defer close(gCChan)
 	worker.NewGarbageCollector(kubeClient, dynamicClient, gCChan, s.GCIntervalMin*60, time.Minute*30).StartCleanup()
 
-	glog.V(1).Infof("********** Start running Kubeturbo Service **********")
-	// Disconnect from Turbo server when Kubeturbo is shutdown
-	handleExit(func() { k8sTAPService.DisconnectFromTurbo() })
-	k8sTAPService.ConnectToTurbo()
+	disconnect := func() {
+		klog.V(1).InfoS("Disconnecting from Turbo server...")
+		k8sTAPService.DisconnectFromTurbo()
+		s.turboConnected.Store(false)
+		promkubeturbo.TurboConnected.Set(0)
+	}
+	startTurboService := func() {
+		klog.V(1).InfoS("********** Start running Kubeturbo Service **********")
+		s.turboConnected.Store(true)
+		promkubeturbo.TurboConnected.Set(1)
+		k8sTAPService.ConnectToTurbo()
+	}
 
-	glog.V(1).Info("Kubeturbo service is stopped.")
+	serviceDone := make(chan struct{})
+	go func() {
+		defer close(serviceDone)
+		if s.LeaderElect {
+			// OnStoppedLeading (and so disconnect) fires automatically once ctx is cancelled.
+			s.runWithLeaderElection(ctx, kubeClient, startTurboService, disconnect)
+		} else {
+			klog.V(2).InfoS("Leader election is disabled")
+			startTurboService()
+		}
+	}()
+
+	<-ctx.Done()
+	klog.V(1).InfoS("Shutdown signal received, waiting for in-flight work to finish", "shutdownGracePeriod", s.ShutdownGracePeriod)
+	select {
+	case <-serviceDone:
+	case <-time.After(s.ShutdownGracePeriod):
+		klog.V(1).InfoS("Shutdown grace period elapsed, forcing disconnect from Turbo")
+	}
+	if !s.LeaderElect {
+		disconnect()
+	}
+	close(gCChan)
+	compatChecker.Stop()
+
+	klog.V(1).InfoS("Kubeturbo service is stopped.")
 }
 
-func (s *VMTServer) startHttp() {
+// parseVolumeCapacityPriorityShape parses --volume-capacity-priority-shape's
+// "utilizationPercent:score" pairs into a volumecapacity.ShapePoint slice, falling back to
+// volumecapacity.DefaultShape() when the flag wasn't set.
+func (s *VMTServer) parseVolumeCapacityPriorityShape() ([]volumecapacity.ShapePoint, error) {
+	if len(s.VolumeCapacityPriorityShape) == 0 {
+		return volumecapacity.DefaultShape(), nil
+	}
+	shape := make([]volumecapacity.ShapePoint, 0, len(s.VolumeCapacityPriorityShape))
+	for _, entry := range s.VolumeCapacityPriorityShape {
+		parts := strings.SplitN(entry, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("malformed shape point %q, expected utilizationPercent:score", entry)
+		}
+		utilization, err := strconv.ParseFloat(parts[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid utilizationPercent in shape point %q: %v", entry, err)
+		}
+		score, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid score in shape point %q: %v", entry, err)
+		}
+		shape = append(shape, volumecapacity.ShapePoint{UtilizationPercent: utilization, Score: score})
+	}
+	return shape, nil
+}
+
+// newActionAdmissionWebhook builds the single outbound admission webhook configured via
+// --action-admission-webhook-*, or returns a nil WebhookAdmitter if --action-admission-webhook-url
+// wasn't set.
+func (s *VMTServer) newActionAdmissionWebhook() (*admission.WebhookAdmitter, error) {
+	if s.ActionAdmissionWebhookURL == "" {
+		return nil, nil
+	}
+	var caBundle []byte
+	if s.ActionAdmissionWebhookCAFile != "" {
+		bundle, err := os.ReadFile(s.ActionAdmissionWebhookCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading --action-admission-webhook-ca-file: %v", err)
+		}
+		caBundle = bundle
+	}
+	return admission.NewWebhookAdmitter(admission.WebhookConfig{
+		Name:          s.ActionAdmissionWebhookURL,
+		URL:           s.ActionAdmissionWebhookURL,
+		CABundle:      caBundle,
+		Timeout:       s.ActionAdmissionWebhookTimeout,
+		FailurePolicy: admission.FailurePolicy(s.ActionAdmissionWebhookFailurePolicy),
+		MatchLabels:   s.ActionAdmissionWebhookMatchLabels,
+	})
+}
+
+// parseTargetClusterKubeconfigs splits each --target-cluster-kubeconfig value on its first "="
+// into a cluster name and kubeconfig path, skipping and logging any entry that doesn't parse.
+func (s *VMTServer) parseTargetClusterKubeconfigs() map[string]string {
+	kubeconfigs := make(map[string]string, len(s.TargetClusterKubeconfigs))
+	for _, entry := range s.TargetClusterKubeconfigs {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			klog.ErrorS(fmt.Errorf("expected name=/path/to/kubeconfig"), "Ignoring malformed --target-cluster-kubeconfig entry", "entry", entry)
+			continue
+		}
+		kubeconfigs[parts[0]] = parts[1]
+	}
+	return kubeconfigs
+}
+
+// runWithLeaderElection only runs onStartedLeading while holding the kubeturbo-namespace Lease
+// named s.LeaderElectResourceName, and runs onStoppedLeading as soon as leadership is lost, so
+// that at most one of several kubeturbo replicas connects to Turbo and executes actions at a
+// time. It emits a Kubernetes Event on each transition via createRecorder so operators can trace
+// failovers.
+func (s *VMTServer) runWithLeaderElection(ctx context.Context, kubeClient *kubernetes.Clientset, onStartedLeading, onStoppedLeading func()) {
+	namespace := os.Getenv("POD_NAMESPACE")
+	if namespace == "" {
+		namespace = "turbo"
+	}
+	identity := os.Getenv("POD_NAME")
+	if identity == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			klog.ErrorS(err, "Failed to determine leader election identity from hostname")
+			os.Exit(1)
+		}
+		identity = hostname
+	}
+
+	recorder := createRecorder(kubeClient)
+	lock, err := resourcelock.New(
+		resourcelock.LeasesResourceLock,
+		namespace,
+		s.LeaderElectResourceName,
+		kubeClient.CoreV1(),
+		kubeClient.CoordinationV1(),
+		resourcelock.ResourceLockConfig{
+			Identity:      identity,
+			EventRecorder: recorder,
+		},
+	)
+	if err != nil {
+		klog.ErrorS(err, "Failed to create leader election resource lock")
+		os.Exit(1)
+	}
+
+	leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+		Lock:          lock,
+		LeaseDuration: s.LeaderElectLeaseDuration,
+		RenewDeadline: s.LeaderElectRenewDeadline,
+		RetryPeriod:   s.LeaderElectRetryPeriod,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				klog.V(1).InfoS("Started leading, connecting to Turbo", "identity", identity)
+				s.isLeader.Store(true)
+				recorder.Eventf(&apiv1.ObjectReference{Kind: "Lease", Namespace: namespace, Name: s.LeaderElectResourceName},
+					apiv1.EventTypeNormal, "LeaderElection", "%s became leader", identity)
+				onStartedLeading()
+			},
+			OnStoppedLeading: func() {
+				klog.V(1).InfoS("Stopped leading, disconnecting from Turbo", "identity", identity)
+				s.isLeader.Store(false)
+				recorder.Eventf(&apiv1.ObjectReference{Kind: "Lease", Namespace: namespace, Name: s.LeaderElectResourceName},
+					apiv1.EventTypeNormal, "LeaderElection", "%s stopped leading", identity)
+				onStoppedLeading()
+			},
+			OnNewLeader: func(newLeader string) {
+				if newLeader != identity {
+					klog.V(2).InfoS("New leader elected", "leader", newLeader)
+				}
+			},
+		},
+	})
+}
+
+// kubeletReachableWithin is the freshness window the "kubelet" readyz check requires: at least
+// one node must have been scraped more recently than this for kubeturbo to be considered ready.
+const kubeletReachableWithin = 5 * time.Minute
+
+// startHttp serves /livez, /readyz, debug and prometheus endpoints until ctx is cancelled, at
+// which point it gracefully shuts the server down instead of dropping in-flight requests.
+//
+// /livez only pings the process itself; /readyz additionally gates on the dependencies kubeturbo
+// needs in order to do useful work: reachability of the API server and of at least one node's
+// kubelet, an active Turbo server connection, and (when leader election is enabled) currently
+// holding the leader lock. /readyz?verbose reports which of these subchecks failed.
+func (s *VMTServer) startHttp(ctx context.Context, kubeClient *kubernetes.Clientset) {
 	mux := http.NewServeMux()
 
-	// healthz
-	healthz.InstallHandler(mux)
+	healthz.InstallPathHandler(mux, "/livez", healthz.PingHealthz)
+
+	readyChecks := []healthz.HealthChecker{
+		healthz.NamedCheck("apiserver", func(r *http.Request) error {
+			_, err := kubeClient.Discovery().ServerVersion()
+			return err
+		}),
+		healthz.NamedCheck("turbo-connection", func(r *http.Request) error {
+			if !s.turboConnected.Load() {
+				return fmt.Errorf("not currently connected to the Turbo server")
+			}
+			return nil
+		}),
+		healthz.NamedCheck("kubelet", func(r *http.Request) error {
+			age, sampled := kubelet.LastSuccessfulScrapeAge()
+			if !sampled {
+				return fmt.Errorf("no node has been scraped via kubelet yet")
+			}
+			if age > kubeletReachableWithin {
+				return fmt.Errorf("no node has been scraped via kubelet in the last %s", kubeletReachableWithin)
+			}
+			return nil
+		}),
+	}
+	if s.LeaderElect {
+		readyChecks = append(readyChecks, healthz.NamedCheck("leader-election", func(r *http.Request) error {
+			if !s.isLeader.Load() {
+				return fmt.Errorf("not currently the leader")
+			}
+			return nil
+		}))
+	}
+	healthz.InstallPathHandler(mux, "/readyz", readyChecks...)
+
+	if s.discoveryHealth != nil {
+		mux.Handle("/healthz/discovery", s.discoveryHealth)
+	}
 
 	// debug
 	if s.EnableProfiling {
@@ -511,96 +1004,55 @@ func (s *VMTServer) startHttp() {
 		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
 		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
 		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	}
 
-		// prometheus.metrics
+	// prometheus.metrics is exposed unconditionally, independent of --profiling, unless a
+	// separate --metrics-bind-address was given.
+	if s.MetricsBindAddress == "" {
 		mux.Handle("/metrics", promhttp.Handler())
+	} else {
+		go s.serveGracefully(ctx, s.MetricsBindAddress, promMux())
 	}
 
-	server := &http.Server{
-		Addr:    net.JoinHostPort(s.Address, strconv.Itoa(s.Port)),
-		Handler: mux,
-	}
-	glog.Fatal(server.ListenAndServe())
+	s.serveGracefully(ctx, net.JoinHostPort(s.Address, strconv.Itoa(s.Port)), mux)
 }
 
-// handleExit disconnects the tap service from Turbo service when Kubeturbo is shotdown
-func handleExit(disconnectFunc disconnectFromTurboFunc) { // k8sTAPService *kubeturbo.K8sTAPService) {
-	glog.V(4).Infof("*** Handling Kubeturbo Termination ***")
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan,
-		syscall.SIGTERM,
-		syscall.SIGINT,
-		syscall.SIGQUIT,
-		syscall.SIGHUP)
-
-	go func() {
-		select {
-		case sig := <-sigChan:
-			// Close the mediation container including the endpoints. It avoids the
-			// invalid endpoints remaining in the server side. See OM-28801.
-			glog.V(2).Infof("Signal %s received. Disconnecting from Turbo server...\n", sig)
-			disconnectFunc()
-		}
-	}()
+// promMux builds a standalone mux serving only /metrics, for use with --metrics-bind-address.
+func promMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	return mux
 }
 
-func discoverk8sAPIResourceGV(client *kubernetes.Clientset, resourceName string) (schema.GroupVersion, error) {
-	// We optimistically use a globally set default if we cannot discover the GV.
-	defaultGV := util.K8sAPIDeploymentReplicasetDefaultGV
-
-	apiResourceLists, err := client.ServerPreferredResources()
-	if apiResourceLists == nil {
-		return defaultGV, err
-	}
-	if err != nil {
-		// We don't exit here as ServerPreferredResources can return the resource list even with errors.
-		glog.Warningf("Error listing api resources: %v", err)
+// serveGracefully runs an http server on addr until ctx is cancelled, at which point it shuts
+// the server down within s.ShutdownGracePeriod instead of dropping in-flight requests.
+func (s *VMTServer) serveGracefully(ctx context.Context, addr string, handler http.Handler) {
+	server := &http.Server{
+		Addr:    addr,
+		Handler: handler,
 	}
 
-	latestExtensionsVersion := schema.GroupVersion{Group: util.K8sExtensionsGroupName, Version: ""}
-	latestAppsVersion := schema.GroupVersion{Group: util.K8sAppsGroupName, Version: ""}
-	for _, apiResourceList := range apiResourceLists {
-		if len(apiResourceList.APIResources) == 0 {
-			continue
-		}
-
-		found := false
-		for _, apiResource := range apiResourceList.APIResources {
-			if apiResource.Name == resourceName {
-				found = true
-				break
-			}
-		}
-		if found == false {
-			continue
-		}
-
-		gv, err := schema.ParseGroupVersion(apiResourceList.GroupVersion)
-		if err != nil {
-			return defaultGV, fmt.Errorf("error parsing GroupVersion: %v", err)
-		}
-
-		group := gv.Group
-		version := gv.Version
-		if group == util.K8sExtensionsGroupName {
-			latestExtensionsVersion.Version = latestComparedVersion(version, latestExtensionsVersion.Version)
-		} else if group == util.K8sAppsGroupName {
-			latestAppsVersion.Version = latestComparedVersion(version, latestAppsVersion.Version)
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), s.ShutdownGracePeriod)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			klog.ErrorS(err, "Error shutting down http server", "addr", addr)
 		}
-	}
+	}()
 
-	if latestAppsVersion.Version != "" {
-		return latestAppsVersion, nil
-	}
-	if latestExtensionsVersion.Version != "" {
-		return latestExtensionsVersion, nil
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		klog.ErrorS(err, "Http server exited", "addr", addr)
+		os.Exit(1)
 	}
-	return defaultGV, nil
 }
 
-func latestComparedVersion(newVersion, existingVersion string) string {
-	if existingVersion != "" && versionhelper.CompareKubeAwareVersionStrings(newVersion, existingVersion) <= 0 {
-		return existingVersion
+// resolveK8sAPIResourceGV resolves resourceName's GroupVersion via gvResolver, falling back to
+// the hardcoded default if the resolver could not be constructed (e.g. the discovery cache
+// directory is not writable).
+func resolveK8sAPIResourceGV(gvResolver *k8sapi.ResourceGVResolver, resourceName string) (schema.GroupVersion, error) {
+	if gvResolver == nil {
+		return util.K8sAPIDeploymentReplicasetDefaultGV, fmt.Errorf("no discovery resolver available")
 	}
-	return newVersion
+	return gvResolver.ResolveGV(resourceName, k8sapi.PreferredGroupOrder(resourceName))
 }
@@ -0,0 +1,136 @@
+// Package admission implements a pluggable pre-execution validator chain for kubeturbo actions,
+// modelled on the mutating/validating admission webhook pattern from the wider Kubernetes
+// ecosystem. It backs the ActionAdmission feature gate: before the executor mutates anything for
+// an action, it should run the action through a Chain and honor a Deny decision by reporting the
+// denial back to the Turbo server as the ActionResult's failure reason, instead of executing.
+package admission
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/turbonomic/kubeturbo/pkg/metrics"
+	"github.com/turbonomic/kubeturbo/pkg/placement/volumecapacity"
+)
+
+// DecisionType is the outcome an ActionAdmitter reaches for a single action.
+type DecisionType string
+
+const (
+	// Allow means the admitter raised no objection to the action.
+	Allow DecisionType = "Allow"
+	// Deny means the action must not be executed; Reason is surfaced to the Turbo server.
+	Deny DecisionType = "Deny"
+	// Mutate means the admitter adjusted the action (e.g. narrowed the target) and execution
+	// should proceed with the mutated Action the admitter returns.
+	Mutate DecisionType = "Mutate"
+)
+
+// Decision is what an ActionAdmitter decided for one Action.
+type Decision struct {
+	Type DecisionType
+	// Reason explains a Deny, or describes what a Mutate changed. Always set for non-Allow.
+	Reason string
+	// Action is the (possibly adjusted) action to execute, set on Mutate. Admitters that only
+	// Allow or Deny may leave this zero; the chain carries the input Action forward unchanged.
+	Action Action
+}
+
+// Action is the minimal description of a proposed kubeturbo action that admitters need in order
+// to validate it. The executor builds one of these from the action it's about to execute.
+type Action struct {
+	// Type is the action kind, e.g. "move", "resize", "provision".
+	Type string
+	// Namespace and TargetName/TargetKind identify the workload or pod the action acts on.
+	Namespace  string
+	TargetName string
+	TargetKind string
+	// Labels are the target's labels, consulted by webhook admitters' matchLabels selector.
+	Labels map[string]string
+
+	// Pod is the pod the action acts on or moves, if the target is pod-shaped.
+	Pod *corev1.Pod
+
+	// DestinationNode is the candidate node for a move action, if any.
+	DestinationNode string
+	// DestinationNodeLabels are DestinationNode's labels, consulted by the affinity admitter to
+	// recompute whether Pod's node affinity and anti-affinity still hold at the destination.
+	DestinationNodeLabels map[string]string
+
+	// Quotas are the namespace's ResourceQuotas with a scope selector, consulted by the quota
+	// scope admitter for a resize action that needs to bump a quota's limits.
+	Quotas []corev1.ResourceQuota
+
+	// PDBs are the PodDisruptionBudgets covering Pod's namespace, consulted by the PDB admitter.
+	PDBs []policyv1.PodDisruptionBudget
+
+	// VolumeCandidates score DestinationNode (and any alternatives) by free PV capacity for a
+	// move action that needs RequiredVolumeBytes of additional capacity.
+	VolumeCandidates []volumecapacity.CandidateNode
+	// RequiredVolumeBytes is the additional PV capacity a move would need on DestinationNode, if
+	// the moving pod has bound PVCs.
+	RequiredVolumeBytes float64
+}
+
+// ActionAdmitter validates or mutates a single proposed Action. Implementations must be safe for
+// concurrent use; the chain may invoke admitters for different actions concurrently.
+type ActionAdmitter interface {
+	// Name identifies the admitter in logs and the per-admitter Prometheus metrics.
+	Name() string
+	// Admit decides whether action may proceed.
+	Admit(ctx context.Context, action Action) (Decision, error)
+}
+
+// Chain runs a sequence of ActionAdmitters over an Action, short-circuiting on the first Deny.
+type Chain struct {
+	admitters []ActionAdmitter
+}
+
+// NewChain returns a Chain that runs admitters in the given order.
+func NewChain(admitters ...ActionAdmitter) *Chain {
+	return &Chain{admitters: admitters}
+}
+
+// Admit runs action through every admitter in the chain in order. It stops and returns the first
+// Deny. A Mutate carries its adjusted Action forward to the remaining admitters. If every
+// admitter Allows (or the chain is empty), Admit returns an Allow decision holding the
+// (possibly mutated) final Action.
+func (c *Chain) Admit(ctx context.Context, action Action) (Decision, error) {
+	current := action
+	for _, admitter := range c.admitters {
+		start := time.Now()
+		decision, err := admitter.Admit(ctx, current)
+		recordAdmission(admitter.Name(), decision.Type, err, time.Since(start))
+		if err != nil {
+			return Decision{}, err
+		}
+
+		switch decision.Type {
+		case Deny:
+			klog.V(2).InfoS("Action denied by admitter", "admitter", admitter.Name(),
+				"actionType", current.Type, "namespace", current.Namespace, "target", current.TargetName,
+				"reason", decision.Reason)
+			return decision, nil
+		case Mutate:
+			klog.V(3).InfoS("Action mutated by admitter", "admitter", admitter.Name(),
+				"actionType", current.Type, "namespace", current.Namespace, "target", current.TargetName,
+				"reason", decision.Reason)
+			current = decision.Action
+		}
+	}
+	return Decision{Type: Allow, Action: current}, nil
+}
+
+// recordAdmission instruments one admitter's decision on the per-admitter Prometheus counters.
+func recordAdmission(admitterName string, decisionType DecisionType, err error, elapsed time.Duration) {
+	result := string(decisionType)
+	if err != nil {
+		result = "Error"
+	}
+	metrics.ActionAdmissionTotal.WithLabelValues(admitterName, result).Inc()
+	metrics.ActionAdmissionDurationSeconds.WithLabelValues(admitterName).Observe(elapsed.Seconds())
+}
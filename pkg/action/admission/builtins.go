@@ -0,0 +1,199 @@
+package admission
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/turbonomic/kubeturbo/pkg/features"
+	"github.com/turbonomic/kubeturbo/pkg/placement/volumecapacity"
+	"github.com/turbonomic/kubeturbo/pkg/quota"
+)
+
+// QuotaScopeAdmitter denies a resize action whose namespace quota increase would have to bump a
+// ResourceQuota whose scope selector doesn't actually admit the resized pod. It reuses the same
+// matching pkg/quota applies for the QuotaScopeSelectorAware feature gate, so the two stay
+// consistent: an action that would be rejected here would also pick the wrong quota there.
+type QuotaScopeAdmitter struct{}
+
+// NewQuotaScopeAdmitter returns a QuotaScopeAdmitter.
+func NewQuotaScopeAdmitter() *QuotaScopeAdmitter { return &QuotaScopeAdmitter{} }
+
+func (a *QuotaScopeAdmitter) Name() string { return "QuotaScope" }
+
+func (a *QuotaScopeAdmitter) Admit(ctx context.Context, action Action) (Decision, error) {
+	if action.Type != "resize" || action.Pod == nil || len(action.Quotas) == 0 {
+		return Decision{Type: Allow}, nil
+	}
+	admitting, err := quota.SelectAdmitting(action.Quotas, action.Pod)
+	if err != nil {
+		return Decision{}, err
+	}
+	if len(admitting) == 0 {
+		return Decision{
+			Type:   Deny,
+			Reason: fmt.Sprintf("no ResourceQuota in namespace %q admits pod %q under its scope selector", action.Namespace, action.TargetName),
+		}, nil
+	}
+	return Decision{Type: Allow}, nil
+}
+
+// PDBAdmitter denies a move action that would evict a pod covered by a PodDisruptionBudget that
+// has no disruption budget left, predicting the eviction kubelet/the eviction API would itself
+// refuse, before kubeturbo ever attempts it.
+type PDBAdmitter struct{}
+
+// NewPDBAdmitter returns a PDBAdmitter.
+func NewPDBAdmitter() *PDBAdmitter { return &PDBAdmitter{} }
+
+func (a *PDBAdmitter) Name() string { return "PodDisruptionBudget" }
+
+func (a *PDBAdmitter) Admit(ctx context.Context, action Action) (Decision, error) {
+	if action.Type != "move" || action.Pod == nil {
+		return Decision{Type: Allow}, nil
+	}
+	podLabels := labels.Set(action.Pod.Labels)
+	for _, pdb := range action.PDBs {
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			return Decision{}, fmt.Errorf("parsing selector of PodDisruptionBudget %s/%s: %v", pdb.Namespace, pdb.Name, err)
+		}
+		if !selector.Matches(podLabels) {
+			continue
+		}
+		if pdb.Status.DisruptionsAllowed < 1 {
+			return Decision{
+				Type: Deny,
+				Reason: fmt.Sprintf("moving pod %q would violate PodDisruptionBudget %q, which has 0 disruptions allowed",
+					action.TargetName, pdb.Name),
+			}, nil
+		}
+	}
+	return Decision{Type: Allow}, nil
+}
+
+// VolumeCapacityAdmitter denies a move action whose DestinationNode has no PV with enough free
+// capacity for the moving pod's bound PVCs, reusing the same scoring pkg/placement/volumecapacity
+// applies for the VolumeCapacityPriority feature gate.
+type VolumeCapacityAdmitter struct {
+	Shape []volumecapacity.ShapePoint
+}
+
+// NewVolumeCapacityAdmitter returns a VolumeCapacityAdmitter that scores against shape.
+func NewVolumeCapacityAdmitter(shape []volumecapacity.ShapePoint) *VolumeCapacityAdmitter {
+	return &VolumeCapacityAdmitter{Shape: shape}
+}
+
+func (a *VolumeCapacityAdmitter) Name() string { return "VolumeCapacity" }
+
+func (a *VolumeCapacityAdmitter) Admit(ctx context.Context, action Action) (Decision, error) {
+	if action.Type != "move" || action.RequiredVolumeBytes <= 0 || len(action.VolumeCandidates) == 0 {
+		return Decision{Type: Allow}, nil
+	}
+	best, _, rejections, err := volumecapacity.BestCandidate(a.Shape, action.VolumeCandidates, action.RequiredVolumeBytes)
+	if err != nil || best != action.DestinationNode {
+		for _, r := range rejections {
+			if r.Node == action.DestinationNode {
+				return Decision{Type: Deny, Reason: r.Reason}, nil
+			}
+		}
+		if err != nil {
+			return Decision{Type: Deny, Reason: err.Error()}, nil
+		}
+	}
+	return Decision{Type: Allow}, nil
+}
+
+// AffinityAdmitter denies a move action whose destination node would violate the moving pod's
+// required node affinity, recomputed against DestinationNodeLabels rather than trusting whatever
+// scored the move as placeable. It registers with features.OnChange for IgnoreAffinities so an
+// operator can disable affinity re-checking mid-incident (see the IgnoreAffinities feature gate)
+// without restarting kubeturbo.
+type AffinityAdmitter struct {
+	// ignored is 1 when IgnoreAffinities is enabled, kept in sync by a features.OnChange
+	// callback; read with atomic.LoadInt32 since Admit can run concurrently with a gate flip.
+	ignored int32
+}
+
+// NewAffinityAdmitter returns an AffinityAdmitter and registers it to react to IgnoreAffinities
+// flips for the lifetime of the process.
+func NewAffinityAdmitter() *AffinityAdmitter {
+	a := &AffinityAdmitter{}
+	features.OnChange(features.IgnoreAffinities, func(enabled bool) {
+		if enabled {
+			atomic.StoreInt32(&a.ignored, 1)
+		} else {
+			atomic.StoreInt32(&a.ignored, 0)
+		}
+	})
+	return a
+}
+
+func (a *AffinityAdmitter) Name() string { return "Affinity" }
+
+func (a *AffinityAdmitter) Admit(ctx context.Context, action Action) (Decision, error) {
+	if atomic.LoadInt32(&a.ignored) == 1 {
+		return Decision{Type: Allow}, nil
+	}
+	if action.Type != "move" || action.Pod == nil || action.Pod.Spec.Affinity == nil || action.Pod.Spec.Affinity.NodeAffinity == nil {
+		return Decision{Type: Allow}, nil
+	}
+	required := action.Pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if required == nil {
+		return Decision{Type: Allow}, nil
+	}
+	nodeLabels := labels.Set(action.DestinationNodeLabels)
+	for _, term := range required.NodeSelectorTerms {
+		if nodeSelectorTermMatches(term, nodeLabels) {
+			return Decision{Type: Allow}, nil
+		}
+	}
+	return Decision{
+		Type: Deny,
+		Reason: fmt.Sprintf("destination node %q does not satisfy pod %q's required node affinity",
+			action.DestinationNode, action.TargetName),
+	}, nil
+}
+
+// nodeSelectorTermMatches reports whether nodeLabels satisfies every match expression of term.
+// Only the Operator values meaningful for labels (In, NotIn, Exists, DoesNotExist) are evaluated;
+// MatchFields terms (node name/zone fields) aren't relevant to a label-only recomputation.
+func nodeSelectorTermMatches(term corev1.NodeSelectorTerm, nodeLabels labels.Set) bool {
+	for _, expr := range term.MatchExpressions {
+		value, exists := nodeLabels[expr.Key]
+		switch expr.Operator {
+		case corev1.NodeSelectorOpIn:
+			if !exists || !contains(expr.Values, value) {
+				return false
+			}
+		case corev1.NodeSelectorOpNotIn:
+			if exists && contains(expr.Values, value) {
+				return false
+			}
+		case corev1.NodeSelectorOpExists:
+			if !exists {
+				return false
+			}
+		case corev1.NodeSelectorOpDoesNotExist:
+			if exists {
+				return false
+			}
+		default:
+			// Gt/Lt and MatchFields terms aren't evaluated here; treat as non-blocking.
+		}
+	}
+	return true
+}
+
+func contains(values []string, v string) bool {
+	for _, candidate := range values {
+		if candidate == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package admission
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/turbonomic/kubeturbo/pkg/features"
+)
+
+func TestAffinityAdmitterHonorsDynamicIgnoreAffinities(t *testing.T) {
+	action := Action{
+		Type: "move",
+		Pod: &corev1.Pod{Spec: corev1.PodSpec{Affinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{
+					{Key: "zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}},
+				}}},
+			},
+		}}}},
+		DestinationNode:       "node1",
+		DestinationNodeLabels: map[string]string{"zone": "b"}, // does not satisfy the required term
+	}
+
+	if err := features.ApplyDynamic("IgnoreAffinities=false"); err != nil {
+		t.Fatalf("ApplyDynamic: %v", err)
+	}
+	a := NewAffinityAdmitter()
+
+	decision, err := a.Admit(context.Background(), action)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decision.Type != Deny {
+		t.Fatalf("expected Deny with IgnoreAffinities off and a non-satisfying destination, got %v", decision.Type)
+	}
+
+	if err := features.ApplyDynamic("IgnoreAffinities=true"); err != nil {
+		t.Fatalf("ApplyDynamic: %v", err)
+	}
+	decision, err = a.Admit(context.Background(), action)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decision.Type != Allow {
+		t.Fatalf("expected Allow once IgnoreAffinities is flipped on without a restart, got %v", decision.Type)
+	}
+
+	// Restore the gate so other tests in this package observe its default.
+	if err := features.ApplyDynamic("IgnoreAffinities=false"); err != nil {
+		t.Fatalf("ApplyDynamic: %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+package admission
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+)
+
+// FailurePolicy is how a WebhookAdmitter behaves when the webhook is unreachable or returns a
+// malformed response, mirroring admissionregistration.k8s.io's FailurePolicyType.
+type FailurePolicy string
+
+const (
+	// FailurePolicyIgnore allows the action through when the webhook call fails.
+	FailurePolicyIgnore FailurePolicy = "Ignore"
+	// FailurePolicyFail denies the action when the webhook call fails. This is the default, to
+	// fail closed rather than silently skip a configured policy check.
+	FailurePolicyFail FailurePolicy = "Fail"
+)
+
+// WebhookConfig describes one outbound admission webhook, the way a single entry of a
+// ValidatingActionWebhookConfiguration-style ConfigMap would: which actions it applies to
+// (MatchLabels, matched against Action.Labels) and how to call it.
+type WebhookConfig struct {
+	// Name identifies this webhook in logs and the per-admitter Prometheus metrics.
+	Name string
+	// URL is the webhook endpoint. Kubeturbo POSTs a JSON WebhookRequest and expects a JSON
+	// WebhookResponse back.
+	URL string
+	// CABundle is the PEM-encoded CA certificate(s) used to verify URL, in addition to the
+	// system trust store. May be empty to rely on the system trust store alone.
+	CABundle []byte
+	// Timeout bounds how long to wait for the webhook to respond.
+	Timeout time.Duration
+	// FailurePolicy controls the decision when the webhook call itself fails. Defaults to
+	// FailurePolicyFail if empty.
+	FailurePolicy FailurePolicy
+	// MatchLabels restricts this webhook to actions whose target carries all of these labels.
+	// A nil/empty MatchLabels matches every action.
+	MatchLabels map[string]string
+}
+
+// WebhookRequest is the JSON payload POSTed to a configured webhook for one action.
+type WebhookRequest struct {
+	ActionType string            `json:"actionType"`
+	Namespace  string            `json:"namespace"`
+	TargetName string            `json:"targetName"`
+	TargetKind string            `json:"targetKind"`
+	Labels     map[string]string `json:"labels,omitempty"`
+}
+
+// WebhookResponse is the JSON response a webhook must return.
+type WebhookResponse struct {
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// WebhookAdmitter delegates the admission decision to an external HTTP service, giving operators
+// an extension point for org-specific policy without forking kubeturbo.
+type WebhookAdmitter struct {
+	config WebhookConfig
+	client *http.Client
+}
+
+// NewWebhookAdmitter builds a WebhookAdmitter from cfg. It returns an error if cfg.CABundle is
+// set but doesn't parse as PEM-encoded certificates.
+func NewWebhookAdmitter(cfg WebhookConfig) (*WebhookAdmitter, error) {
+	if cfg.FailurePolicy == "" {
+		cfg.FailurePolicy = FailurePolicyFail
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+
+	transport := &http.Transport{}
+	if len(cfg.CABundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(cfg.CABundle) {
+			return nil, fmt.Errorf("webhook %q: CABundle does not contain any valid PEM certificates", cfg.Name)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+
+	return &WebhookAdmitter{
+		config: cfg,
+		client: &http.Client{Timeout: cfg.Timeout, Transport: transport},
+	}, nil
+}
+
+func (a *WebhookAdmitter) Name() string { return "Webhook:" + a.config.Name }
+
+func (a *WebhookAdmitter) Admit(ctx context.Context, action Action) (Decision, error) {
+	if !a.matches(action) {
+		return Decision{Type: Allow}, nil
+	}
+
+	decision, err := a.call(ctx, action)
+	if err != nil {
+		if a.config.FailurePolicy == FailurePolicyIgnore {
+			klog.InfoS("Webhook admitter call failed, allowing action under FailurePolicy Ignore",
+				"webhook", a.config.Name, "err", err)
+			return Decision{Type: Allow}, nil
+		}
+		return Decision{Type: Deny, Reason: fmt.Sprintf("webhook %q call failed: %v", a.config.Name, err)}, nil
+	}
+	return decision, nil
+}
+
+func (a *WebhookAdmitter) matches(action Action) bool {
+	if len(a.config.MatchLabels) == 0 {
+		return true
+	}
+	return labels.SelectorFromSet(a.config.MatchLabels).Matches(labels.Set(action.Labels))
+}
+
+func (a *WebhookAdmitter) call(ctx context.Context, action Action) (Decision, error) {
+	body, err := json.Marshal(WebhookRequest{
+		ActionType: action.Type,
+		Namespace:  action.Namespace,
+		TargetName: action.TargetName,
+		TargetKind: action.TargetKind,
+		Labels:     action.Labels,
+	})
+	if err != nil {
+		return Decision{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return Decision{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return Decision{}, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Decision{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return Decision{}, fmt.Errorf("webhook returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var webhookResp WebhookResponse
+	if err := json.Unmarshal(respBody, &webhookResp); err != nil {
+		return Decision{}, fmt.Errorf("decoding webhook response: %v", err)
+	}
+	if !webhookResp.Allowed {
+		return Decision{Type: Deny, Reason: webhookResp.Reason}, nil
+	}
+	return Decision{Type: Allow}, nil
+}
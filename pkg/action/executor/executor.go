@@ -0,0 +1,20 @@
+// Package executor holds helpers consulted before an action mutates the target cluster.
+package executor
+
+import (
+	clusterclient "github.com/openshift/machine-api-operator/pkg/generated/clientset/versioned"
+	"k8s.io/client-go/kubernetes"
+)
+
+// IsClusterAPIEnabled reports whether the target cluster has the Cluster API CRDs installed and
+// reachable, so move actions that relocate a workload onto a different Machine/MachineSet can be
+// offered only when the cluster actually supports them.
+func IsClusterAPIEnabled(caClient clusterclient.Interface, kubeClient kubernetes.Interface) bool {
+	if caClient == nil || kubeClient == nil {
+		return false
+	}
+	if _, err := caClient.Discovery().ServerResourcesForGroupVersion("machine.openshift.io/v1beta1"); err != nil {
+		return false
+	}
+	return true
+}
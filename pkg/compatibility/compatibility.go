@@ -0,0 +1,209 @@
+// Package compatibility answers, for a move/resize action that would relocate a workload onto a
+// different cluster, whether the target cluster actually serves the workload's owning controller
+// GroupVersionKind. It exists so an unsupported relocation (e.g. moving a workload whose owner is
+// only served as extensions/v1beta1 onto a cluster that has since dropped that API) is rejected
+// up front with a structured reason, instead of failing partway through execution.
+package compatibility
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	diskcached "k8s.io/client-go/discovery/cached/disk"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+)
+
+// DefaultResyncInterval mirrors k8sapi.DefaultResyncInterval: how often each target cluster's
+// discovery cache is invalidated so GVK support picked up from a target-cluster upgrade (or
+// API removal) without recreating the Checker.
+const DefaultResyncInterval = 10 * time.Minute
+
+// crossGroupFallback lists, per Kind, the groups that have historically served it, so a Kind
+// served under apps/v1 on the source cluster is still recognized as compatible with a target
+// cluster that only advertises it under the older extensions/v1beta1 group, or vice versa.
+var crossGroupFallback = map[string][]string{
+	"Deployment":  {"apps", "extensions"},
+	"ReplicaSet":  {"apps", "extensions"},
+	"DaemonSet":   {"apps", "extensions"},
+	"StatefulSet": {"apps"},
+}
+
+// clusterGVKs is the set of (Group, Kind) -> served versions advertised by a cluster, as reported
+// by ServerPreferredResources().
+type clusterGVKs map[schema.GroupKind]map[string]bool
+
+// discoverClusterGVKs walks ServerPreferredResources(), tolerating the partial result that
+// ErrGroupDiscoveryFailed can return alongside an error when an aggregated APIService is down,
+// and returns every (Group, Kind) the cluster currently serves.
+func discoverClusterGVKs(client discovery.DiscoveryInterface) (clusterGVKs, error) {
+	apiResourceLists, err := client.ServerPreferredResources()
+	if apiResourceLists == nil {
+		return nil, err
+	}
+	if err != nil {
+		klog.ErrorS(err, "Discovery returned errors, continuing with partial results")
+	}
+
+	gvks := make(clusterGVKs)
+	for _, apiResourceList := range apiResourceLists {
+		gv, parseErr := schema.ParseGroupVersion(apiResourceList.GroupVersion)
+		if parseErr != nil {
+			klog.ErrorS(parseErr, "Error parsing discovered GroupVersion", "groupVersion", apiResourceList.GroupVersion)
+			continue
+		}
+		for _, apiResource := range apiResourceList.APIResources {
+			gk := schema.GroupKind{Group: gv.Group, Kind: apiResource.Kind}
+			if gvks[gk] == nil {
+				gvks[gk] = make(map[string]bool)
+			}
+			gvks[gk][gv.Version] = true
+		}
+	}
+	return gvks, nil
+}
+
+// serves reports whether gvks serves gvk, falling back across the groups in crossGroupFallback
+// for gvk.Kind when the exact group isn't present.
+func (gvks clusterGVKs) serves(gvk schema.GroupVersionKind) bool {
+	if versions, ok := gvks[gvk.GroupKind()]; ok && versions[gvk.Version] {
+		return true
+	}
+	for _, group := range crossGroupFallback[gvk.Kind] {
+		if _, ok := gvks[schema.GroupKind{Group: group, Kind: gvk.Kind}]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Workload identifies a namespaced object whose owning controller GVK is being checked for
+// compatibility with a relocation target.
+type Workload struct {
+	Namespace string
+	Name      string
+	OwnerGVK  schema.GroupVersionKind
+}
+
+// Rejection is the structured reason surfaced back to the Turbo server when a move/resize action
+// is rejected up front because the target cluster does not serve the workload's owner GVK.
+type Rejection struct {
+	TargetCluster string
+	Namespace     string
+	Name          string
+	OwnerGVK      schema.GroupVersionKind
+	Reason        string
+}
+
+// Report is the result of checking a batch of workloads against a single target cluster, grouped
+// by namespace so a caller can reject an entire namespace's pending actions in one pass.
+type Report struct {
+	TargetCluster string
+	Incompatible  map[string][]Rejection
+}
+
+type targetCluster struct {
+	name  string
+	cache discovery.CachedDiscoveryInterface
+	stop  chan struct{}
+}
+
+// Checker holds the discovered GVK sets for the local cluster and every configured target
+// cluster, refreshing each on a resync interval so it can be queried repeatedly without re-paying
+// the discovery cost per action.
+type Checker struct {
+	sourceClient discovery.DiscoveryInterface
+
+	mu      sync.RWMutex
+	targets map[string]*targetCluster
+}
+
+// NewChecker builds a Checker for the given source (local) cluster's discovery client. Target
+// clusters are added via AddTarget once their kubeconfig/rest.Config is available.
+func NewChecker(sourceClient discovery.DiscoveryInterface) *Checker {
+	return &Checker{
+		sourceClient: sourceClient,
+		targets:      make(map[string]*targetCluster),
+	}
+}
+
+// AddTarget registers a target cluster under name, backed by an on-disk cached discovery client
+// for config, and starts a background goroutine that invalidates the cache every resyncInterval.
+func (c *Checker) AddTarget(name string, config *rest.Config, resyncInterval time.Duration) error {
+	cache, err := diskcached.NewCachedDiscoveryClientForConfig(config, "", "", resyncInterval)
+	if err != nil {
+		return fmt.Errorf("failed to create cached discovery client for target cluster %q: %v", name, err)
+	}
+	if resyncInterval <= 0 {
+		resyncInterval = DefaultResyncInterval
+	}
+
+	t := &targetCluster{name: name, cache: cache, stop: make(chan struct{})}
+	ticker := time.NewTicker(resyncInterval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				klog.V(3).InfoS("Invalidating target cluster discovery cache", "targetCluster", name)
+				t.cache.Invalidate()
+			case <-t.stop:
+				return
+			}
+		}
+	}()
+
+	c.mu.Lock()
+	c.targets[name] = t
+	c.mu.Unlock()
+	return nil
+}
+
+// Stop tears down the discovery resync goroutine for every registered target cluster. It is
+// called from the same shutdown path that disconnects from the Turbo server on SIGTERM/SIGINT,
+// so target-cluster watchers don't leak past kubeturbo's own lifecycle.
+func (c *Checker) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for name, t := range c.targets {
+		close(t.stop)
+		delete(c.targets, name)
+	}
+}
+
+// CheckWorkloads rejects every workload in workloads whose OwnerGVK is not served by the named
+// target cluster, grouped by namespace. An action executor should consult this report and reject
+// the affected move/resize actions up front rather than attempting execution against the target.
+func (c *Checker) CheckWorkloads(targetCluster string, workloads []Workload) (*Report, error) {
+	c.mu.RLock()
+	t, ok := c.targets[targetCluster]
+	c.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown target cluster %q", targetCluster)
+	}
+
+	targetGVKs, err := discoverClusterGVKs(t.cache)
+	if err != nil && targetGVKs == nil {
+		return nil, fmt.Errorf("failed to discover APIs on target cluster %q: %v", targetCluster, err)
+	}
+
+	report := &Report{TargetCluster: targetCluster, Incompatible: make(map[string][]Rejection)}
+	for _, w := range workloads {
+		if targetGVKs.serves(w.OwnerGVK) {
+			continue
+		}
+		rejection := Rejection{
+			TargetCluster: targetCluster,
+			Namespace:     w.Namespace,
+			Name:          w.Name,
+			OwnerGVK:      w.OwnerGVK,
+			Reason: fmt.Sprintf("target cluster %q does not serve %s, the owning controller API for %s/%s",
+				targetCluster, w.OwnerGVK, w.Namespace, w.Name),
+		}
+		report.Incompatible[w.Namespace] = append(report.Incompatible[w.Namespace], rejection)
+	}
+	return report, nil
+}
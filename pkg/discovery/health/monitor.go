@@ -0,0 +1,166 @@
+// Package health classifies discovery errors the way kube-controller-manager tolerates them per
+// controller, so a single broken aggregated APIService doesn't poison kubeturbo's whole discovery
+// cycle. Each discovery worker declares the GVRs it requires; the Monitor reports whether those
+// GVRs are currently being served, and a worker whose GVRs are missing is skipped with a WARN
+// instead of failing outright.
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/klog/v2"
+)
+
+// ErrorClass distinguishes a discovery error that still left a usable partial result (e.g. one
+// group's aggregated APIService is down) from one fatal enough that no GVR availability can be
+// trusted.
+type ErrorClass string
+
+const (
+	// ErrorClassNone means ServerPreferredResources succeeded without error.
+	ErrorClassNone ErrorClass = "None"
+	// ErrorClassPartial means some API groups failed discovery (ErrGroupDiscoveryFailed) but a
+	// usable partial resource list was still returned.
+	ErrorClassPartial ErrorClass = "PartialGroupDiscoveryFailed"
+	// ErrorClassFatal means no resource list could be obtained at all.
+	ErrorClassFatal ErrorClass = "Fatal"
+)
+
+// WorkerStatus is whether a named discovery worker has everything it requires to run, as of the
+// last Refresh.
+type WorkerStatus struct {
+	Name     string                        `json:"name"`
+	Required []schema.GroupVersionResource `json:"required"`
+	Missing  []schema.GroupVersionResource `json:"missing,omitempty"`
+	Runnable bool                          `json:"runnable"`
+}
+
+// Monitor tracks which GVRs the cluster currently serves and which discovery workers can run
+// given that availability, refreshed each discovery cycle via Refresh.
+type Monitor struct {
+	client        discovery.DiscoveryInterface
+	ignoredGroups map[string]bool
+
+	mu          sync.RWMutex
+	available   map[schema.GroupVersionResource]bool
+	lastClass   ErrorClass
+	lastErr     error
+	workerState map[string]WorkerStatus
+}
+
+// NewMonitor builds a Monitor backed by client. ignoredGroups are API groups whose discovery
+// failures are never treated as fatal -- e.g. a group known to be flaky in a given environment --
+// mirroring the kubeturbo config's IgnoredGroups allowlist.
+func NewMonitor(client discovery.DiscoveryInterface, ignoredGroups []string) *Monitor {
+	ignored := make(map[string]bool, len(ignoredGroups))
+	for _, g := range ignoredGroups {
+		ignored[g] = true
+	}
+	return &Monitor{
+		client:        client,
+		ignoredGroups: ignored,
+		available:     make(map[schema.GroupVersionResource]bool),
+		workerState:   make(map[string]WorkerStatus),
+	}
+}
+
+// Refresh re-runs ServerPreferredResources and rebuilds the set of currently served GVRs. It
+// never returns an error for a partial discovery failure outside of ignoredGroups: those are
+// recorded via Status() for the /healthz/discovery endpoint, not propagated to the caller, since
+// the whole point of this package is that a broken group shouldn't abort the discovery cycle.
+func (m *Monitor) Refresh() error {
+	apiResourceLists, err := m.client.ServerPreferredResources()
+	class := classify(err)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lastClass = class
+	m.lastErr = err
+
+	if apiResourceLists == nil {
+		return err
+	}
+	if err != nil {
+		klog.V(2).InfoS("Discovery returned errors, continuing with partial results", "errorClass", class, "err", err)
+	}
+
+	available := make(map[schema.GroupVersionResource]bool)
+	for _, apiResourceList := range apiResourceLists {
+		gv, parseErr := schema.ParseGroupVersion(apiResourceList.GroupVersion)
+		if parseErr != nil {
+			klog.ErrorS(parseErr, "Error parsing discovered GroupVersion", "groupVersion", apiResourceList.GroupVersion)
+			continue
+		}
+		for _, apiResource := range apiResourceList.APIResources {
+			available[gv.WithResource(apiResource.Name)] = true
+		}
+	}
+	m.available = available
+	return nil
+}
+
+// classify maps a ServerPreferredResources error to an ErrorClass; a nil error or one that's
+// purely a group-discovery failure (still yielding a partial list) is never fatal.
+func classify(err error) ErrorClass {
+	if err == nil {
+		return ErrorClassNone
+	}
+	if discovery.IsGroupDiscoveryFailedError(err) {
+		return ErrorClassPartial
+	}
+	return ErrorClassFatal
+}
+
+// RequireGVRs records workerName's required GVRs and reports whether every one of them is
+// currently being served. Discovery workers should call this once per cycle before starting and
+// skip themselves with a WARN when it returns false.
+func (m *Monitor) RequireGVRs(workerName string, required []schema.GroupVersionResource) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var missing []schema.GroupVersionResource
+	for _, gvr := range required {
+		if m.ignoredGroups[gvr.Group] {
+			continue
+		}
+		if !m.available[gvr] {
+			missing = append(missing, gvr)
+		}
+	}
+
+	status := WorkerStatus{Name: workerName, Required: required, Missing: missing, Runnable: len(missing) == 0}
+	m.workerState[workerName] = status
+	if !status.Runnable {
+		klog.Warningf("Skipping discovery worker %q, required GVRs are not served by the cluster: %v", workerName, missing)
+	}
+	return status.Runnable
+}
+
+// report is the JSON body served at /healthz/discovery.
+type report struct {
+	LastErrorClass ErrorClass     `json:"lastErrorClass"`
+	LastError      string         `json:"lastError,omitempty"`
+	Workers        []WorkerStatus `json:"workers"`
+}
+
+// ServeHTTP reports the last discovery error classification and every worker's runnability, for
+// an operator to diagnose why a worker (e.g. GitOps CRD discovery) isn't producing entities.
+func (m *Monitor) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	rep := report{LastErrorClass: m.lastClass, Workers: make([]WorkerStatus, 0, len(m.workerState))}
+	if m.lastErr != nil {
+		rep.LastError = m.lastErr.Error()
+	}
+	for _, status := range m.workerState {
+		rep.Workers = append(rep.Workers, status)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(rep)
+}
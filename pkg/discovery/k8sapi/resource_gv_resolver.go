@@ -0,0 +1,188 @@
+// Package k8sapi resolves the preferred group/version for Kubernetes API resources whose
+// GroupVersionKind has changed across cluster versions (e.g. extensions/v1beta1 -> apps/v1 for
+// Deployment/ReplicaSet), using a shared, cached discovery client so the probe only runs once per
+// resync interval instead of once per component startup.
+package k8sapi
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	versionhelper "k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
+	diskcached "k8s.io/client-go/discovery/cached/disk"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+
+	"github.com/turbonomic/kubeturbo/pkg/util"
+)
+
+// DefaultResyncInterval is how often a ResourceGVResolver re-probes ServerPreferredResources to
+// pick up API additions/removals (e.g. a beta API being removed after a cluster upgrade).
+const DefaultResyncInterval = 10 * time.Minute
+
+// DefaultCacheTTL bounds how long the on-disk discovery cache is trusted before it is treated as
+// stale and re-fetched from the API server, independent of the Invalidate() resync loop below.
+const DefaultCacheTTL = 10 * time.Minute
+
+// preferredGroupOrder lists, for a given resource name, the groups kubeturbo should prefer in
+// order when the server advertises the resource under more than one of them. This lets the
+// resolver land on the modern API even when a cluster still advertises both, rather than picking
+// whichever group happens to sort "latest" lexically.
+var preferredGroupOrder = map[string][]string{
+	util.DeploymentResName: {util.K8sAppsGroupName, util.K8sExtensionsGroupName},
+	util.ReplicaSetResName: {util.K8sAppsGroupName, util.K8sExtensionsGroupName},
+}
+
+// PreferredGroupOrder returns the configured group preference order for resourceName (e.g.
+// apps before extensions for Deployments/ReplicaSets), or nil if resourceName has no entry, in
+// which case ResolveGV falls back to discovery order. ResolveGV applies the same table itself
+// when it is passed no preferred groups.
+func PreferredGroupOrder(resourceName string) []string {
+	return preferredGroupOrder[resourceName]
+}
+
+// ResourceGVResolver resolves the GroupVersion a cluster currently serves a resource under,
+// backed by a discovery.CachedDiscoveryInterface so repeated lookups (one per discovery worker,
+// one per action executor, etc.) pay the ServerPreferredResources() cost only once per resync.
+type ResourceGVResolver struct {
+	cache discovery.CachedDiscoveryInterface
+
+	mu          sync.RWMutex
+	lastResolve map[string]schema.GroupVersion
+}
+
+// NewResourceGVResolver builds a ResourceGVResolver backed by an on-disk, TTL'd discovery cache
+// keyed by server version, as used by kubectl. cacheDir and httpCacheDir may be empty to fall
+// back to the default os-specific kubectl cache locations.
+func NewResourceGVResolver(config *rest.Config, cacheDir, httpCacheDir string) (*ResourceGVResolver, error) {
+	cache, err := diskcached.NewCachedDiscoveryClientForConfig(config, cacheDir, httpCacheDir, DefaultCacheTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cached discovery client: %v", err)
+	}
+	return &ResourceGVResolver{
+		cache:       cache,
+		lastResolve: make(map[string]schema.GroupVersion),
+	}, nil
+}
+
+// StartResync invalidates the discovery cache every resyncInterval, so a cluster upgrade (e.g. a
+// beta API's removal) is picked up without restarting kubeturbo. It runs until stopCh is closed.
+func (r *ResourceGVResolver) StartResync(stopCh <-chan struct{}, resyncInterval time.Duration) {
+	if resyncInterval <= 0 {
+		resyncInterval = DefaultResyncInterval
+	}
+	ticker := time.NewTicker(resyncInterval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				klog.V(3).InfoS("Invalidating cached discovery information", "resyncInterval", resyncInterval)
+				r.cache.Invalidate()
+			case <-stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// ResolveGV returns the GroupVersion that the cluster currently serves resourceName under,
+// preferring the groups in preferredGroups, in order. If resourceName has no configured
+// preference table entry, the groups from apiResourceList are considered in discovery order.
+// ErrGroupDiscoveryFailed from an aggregated APIService outage is tolerated: partial results are
+// still used rather than discarding everything and falling back to the last known GV.
+func (r *ResourceGVResolver) ResolveGV(resourceName string, preferredGroups []string) (schema.GroupVersion, error) {
+	if len(preferredGroups) == 0 {
+		preferredGroups = preferredGroupOrder[resourceName]
+	}
+
+	apiResourceLists, err := r.cache.ServerPreferredResources()
+	if apiResourceLists == nil {
+		return r.fallback(resourceName, err)
+	}
+	if err != nil {
+		// ServerPreferredResources can return a partial list alongside ErrGroupDiscoveryFailed
+		// when an aggregated APIService is unavailable; the partial list is still usable.
+		klog.ErrorS(err, "Discovery returned errors, continuing with partial results", "resource", resourceName)
+	}
+
+	found := make(map[string]schema.GroupVersion)
+	var discoveryOrder []string
+	for _, apiResourceList := range apiResourceLists {
+		if len(apiResourceList.APIResources) == 0 {
+			continue
+		}
+		hasResource := false
+		for _, apiResource := range apiResourceList.APIResources {
+			if apiResource.Name == resourceName {
+				hasResource = true
+				break
+			}
+		}
+		if !hasResource {
+			continue
+		}
+		gv, parseErr := schema.ParseGroupVersion(apiResourceList.GroupVersion)
+		if parseErr != nil {
+			klog.ErrorS(parseErr, "Error parsing discovered GroupVersion", "groupVersion", apiResourceList.GroupVersion)
+			continue
+		}
+		if existing, ok := found[gv.Group]; !ok {
+			found[gv.Group] = gv
+			discoveryOrder = append(discoveryOrder, gv.Group)
+		} else if versionhelper.CompareKubeAwareVersionStrings(gv.Version, existing.Version) > 0 {
+			found[gv.Group] = gv
+		}
+	}
+
+	// When resourceName has no configured preference table entry, preferredGroups is empty and
+	// the groups found are considered in the order they were first seen in apiResourceLists
+	// (discovery order), rather than never matching anything.
+	groupOrder := preferredGroups
+	if len(groupOrder) == 0 {
+		groupOrder = discoveryOrder
+	}
+
+	for _, group := range groupOrder {
+		if gv, ok := found[group]; ok {
+			r.remember(resourceName, gv)
+			return gv, nil
+		}
+	}
+
+	return r.fallback(resourceName, fmt.Errorf("resource %q not found under any of the preferred groups %v", resourceName, preferredGroups))
+}
+
+// ResolveGVK is a convenience wrapper around ResolveGV for callers that already have a GroupKind,
+// e.g. action executors deciding which API to issue an update against.
+func (r *ResourceGVResolver) ResolveGVK(resourceName string, kind schema.GroupKind) (schema.GroupVersionKind, error) {
+	gv, err := r.ResolveGV(resourceName, preferredGroupOrder[resourceName])
+	if err != nil {
+		return schema.GroupVersionKind{}, err
+	}
+	return gv.WithKind(kind.Kind), nil
+}
+
+// fallback returns the last successfully resolved GroupVersion for resourceName, if any,
+// otherwise the package-wide default, so a transient discovery outage degrades gracefully
+// instead of kubeturbo failing outright.
+func (r *ResourceGVResolver) fallback(resourceName string, cause error) (schema.GroupVersion, error) {
+	r.mu.RLock()
+	gv, ok := r.lastResolve[resourceName]
+	r.mu.RUnlock()
+	if ok {
+		klog.V(2).InfoS("Falling back to last known GroupVersion after discovery error",
+			"resource", resourceName, "groupVersion", gv, "err", cause)
+		return gv, nil
+	}
+	return util.K8sAPIDeploymentReplicasetDefaultGV, cause
+}
+
+func (r *ResourceGVResolver) remember(resourceName string, gv schema.GroupVersion) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastResolve[resourceName] = gv
+}
@@ -0,0 +1,117 @@
+package k8sapi
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/turbonomic/kubeturbo/pkg/util"
+)
+
+// cachedFakeDiscovery adapts fakediscovery.FakeDiscovery (which only implements
+// discovery.DiscoveryInterface) to discovery.CachedDiscoveryInterface, which is all
+// ResourceGVResolver needs from its backing cache in these tests.
+type cachedFakeDiscovery struct {
+	*fakediscovery.FakeDiscovery
+}
+
+func (c *cachedFakeDiscovery) Fresh() bool { return true }
+func (c *cachedFakeDiscovery) Invalidate() {}
+
+// ServerPreferredResources serves the fake's Resources; fakediscovery.FakeDiscovery's own
+// ServerPreferredResources always returns nil, which would leave ResolveGV nothing to resolve.
+func (c *cachedFakeDiscovery) ServerPreferredResources() ([]*metav1.APIResourceList, error) {
+	return c.Resources, nil
+}
+
+func newResolverWithResources(t *testing.T, lists []*metav1.APIResourceList) *ResourceGVResolver {
+	t.Helper()
+	client := fake.NewSimpleClientset()
+	fakeDisc, ok := client.Discovery().(*fakediscovery.FakeDiscovery)
+	if !ok {
+		t.Fatalf("expected *fakediscovery.FakeDiscovery")
+	}
+	fakeDisc.Resources = lists
+	return &ResourceGVResolver{
+		cache:       &cachedFakeDiscovery{fakeDisc},
+		lastResolve: make(map[string]schema.GroupVersion),
+	}
+}
+
+func TestResolveGVPrefersConfiguredGroupOrder(t *testing.T) {
+	resolver := newResolverWithResources(t, []*metav1.APIResourceList{
+		{GroupVersion: "extensions/v1beta1", APIResources: []metav1.APIResource{{Name: util.DeploymentResName}}},
+		{GroupVersion: "apps/v1", APIResources: []metav1.APIResource{{Name: util.DeploymentResName}}},
+	})
+
+	gv, err := resolver.ResolveGV(util.DeploymentResName, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gv != (schema.GroupVersion{Group: util.K8sAppsGroupName, Version: "v1"}) {
+		t.Fatalf("expected apps/v1 to be preferred over extensions/v1beta1, got %v", gv)
+	}
+}
+
+func TestResolveGVFallsBackToDiscoveryOrderWithoutPreferenceEntry(t *testing.T) {
+	// "widgets" has no entry in preferredGroupOrder, so ResolveGV must fall through to
+	// whichever group it was first discovered under rather than failing to match anything.
+	resolver := newResolverWithResources(t, []*metav1.APIResourceList{
+		{GroupVersion: "widgets.example.com/v1", APIResources: []metav1.APIResource{{Name: "widgets"}}},
+	})
+
+	gv, err := resolver.ResolveGV("widgets", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := schema.GroupVersion{Group: "widgets.example.com", Version: "v1"}
+	if gv != want {
+		t.Fatalf("ResolveGV() = %v, want %v", gv, want)
+	}
+}
+
+func TestPreferredGroupOrder(t *testing.T) {
+	want := []string{util.K8sAppsGroupName, util.K8sExtensionsGroupName}
+	if got := PreferredGroupOrder(util.DeploymentResName); !equalStrings(got, want) {
+		t.Errorf("PreferredGroupOrder(%q) = %v, want %v", util.DeploymentResName, got, want)
+	}
+	if got := PreferredGroupOrder("widgets"); got != nil {
+		t.Errorf("PreferredGroupOrder(%q) = %v, want nil for a resource with no preference entry", "widgets", got)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestResolveGVFallsBackToLastKnownOnDiscoveryError(t *testing.T) {
+	resolver := newResolverWithResources(t, []*metav1.APIResourceList{
+		{GroupVersion: "apps/v1", APIResources: []metav1.APIResource{{Name: util.DeploymentResName}}},
+	})
+	if _, err := resolver.ResolveGV(util.DeploymentResName, nil); err != nil {
+		t.Fatalf("seeding resolve: unexpected error: %v", err)
+	}
+
+	// Simulate a subsequent discovery outage: no resources served at all.
+	fakeDisc := resolver.cache.(*cachedFakeDiscovery).FakeDiscovery
+	fakeDisc.Resources = nil
+
+	gv, err := resolver.ResolveGV(util.DeploymentResName, nil)
+	if err != nil {
+		t.Fatalf("expected the last known GroupVersion to be returned without error, got %v", err)
+	}
+	if gv != (schema.GroupVersion{Group: util.K8sAppsGroupName, Version: "v1"}) {
+		t.Fatalf("expected fallback to the last known apps/v1, got %v", gv)
+	}
+}
@@ -0,0 +1,26 @@
+package metrics
+
+// Additional ResourceType commodity kinds reporting how close a pod or
+// container is running to its configured resources.limits/requests, and to
+// the allocatable capacity of the node it is running on. These are derived
+// metrics (a ratio of Used to Capacity) computed by the monitors rather than
+// read verbatim off the kubelet summary API.
+const (
+	// CPULimitUtilization is the ratio of cpu used to the container's
+	// (or, at pod level, the summed containers') cpu limit.
+	CPULimitUtilization ResourceType = "CPULimitUtilization"
+	// CPURequestUtilization is the ratio of cpu used to the container's
+	// (or, at pod level, the summed containers') cpu request.
+	CPURequestUtilization ResourceType = "CPURequestUtilization"
+	// MemoryLimitUtilization is the ratio of memory used to the container's
+	// (or, at pod level, the summed containers') memory limit.
+	MemoryLimitUtilization ResourceType = "MemoryLimitUtilization"
+	// MemoryRequestUtilization is the ratio of memory used to the container's
+	// (or, at pod level, the summed containers') memory request.
+	MemoryRequestUtilization ResourceType = "MemoryRequestUtilization"
+	// MemoryNodeUtilization is the ratio of memory used to the allocatable
+	// memory of the node the pod/container is running on. Unlike the
+	// limit/request ratios above this is always computable, even when no
+	// limit or request is set on the container.
+	MemoryNodeUtilization ResourceType = "MemoryNodeUtilization"
+)
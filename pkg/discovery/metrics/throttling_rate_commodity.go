@@ -0,0 +1,6 @@
+package metrics
+
+// VCPUThrottlingRate is the throttled_periods/total_periods ratio computed over a rolling
+// window of recent samples, as opposed to VCPUThrottling which carries the raw cumulative
+// counters read straight off the kubelet cadvisor metrics.
+const VCPUThrottlingRate ResourceType = "VCPUThrottlingRate"
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	api "k8s.io/api/core/v1"
@@ -20,8 +21,9 @@ import (
 
 	dto "github.com/prometheus/client_model/go"
 
-	"github.com/golang/glog"
 	"github.com/turbonomic/kubeturbo/pkg/kubeclient"
+	promkubeturbo "github.com/turbonomic/kubeturbo/pkg/metrics"
+	"k8s.io/klog/v2"
 )
 
 // KubeletMonitor is a resource monitoring worker.
@@ -41,18 +43,61 @@ type KubeletMonitor struct {
 
 	// Whether this kubelet monitor runs during full discovery
 	isFullDiscovery bool
+
+	// containerSpecResources holds the container resources.limits/requests
+	// declared on the pod spec, keyed by the container metric id
+	// (namespace/pod/container), as supplied by the discovery Task.
+	containerSpecResources map[string]*task.ContainerResources
+
+	// throttlingTracker keeps a rolling window of recent throttling samples per container so a
+	// delta-based throttling rate can be derived locally. It must survive reset(), since reset
+	// only replaces the sink for the next task while this KubeletMonitor instance, and the
+	// window it has accumulated, persists across tasks.
+	throttlingTracker *throttlingTracker
+}
+
+// DefaultThrottlingWindowSize is the number of recent scrapes kept per container to compute the
+// rolling throttling rate.
+const DefaultThrottlingWindowSize = 10
+
+// lastSuccessfulScrapeUnixNano tracks, across all KubeletMonitor instances, the wall-clock time
+// of the most recent successful kubelet summary scrape. It backs the kubelet-reachability
+// readiness check exposed by the kubeturbo http server, which has no other way to tell whether
+// at least one node has been sampled recently.
+var lastSuccessfulScrapeUnixNano int64
+
+func recordSuccessfulScrape() {
+	atomic.StoreInt64(&lastSuccessfulScrapeUnixNano, time.Now().UnixNano())
+}
+
+// LastSuccessfulScrapeAge returns how long ago the last kubelet summary scrape succeeded, and
+// false if no scrape has ever succeeded.
+func LastSuccessfulScrapeAge() (time.Duration, bool) {
+	ts := atomic.LoadInt64(&lastSuccessfulScrapeUnixNano)
+	if ts == 0 {
+		return 0, false
+	}
+	return time.Since(time.Unix(0, ts)), true
 }
 
 func NewKubeletMonitor(config *KubeletMonitorConfig, isFullDiscovery bool) (*KubeletMonitor, error) {
 	return &KubeletMonitor{
-		kubeletClient:   config.kubeletClient,
-		kubeClient:      config.kubeClient,
-		metricSink:      metrics.NewEntityMetricSink(),
-		stopCh:          make(chan struct{}, 1),
-		isFullDiscovery: isFullDiscovery,
+		kubeletClient:     config.kubeletClient,
+		kubeClient:        config.kubeClient,
+		metricSink:        metrics.NewEntityMetricSink(),
+		stopCh:            make(chan struct{}, 1),
+		isFullDiscovery:   isFullDiscovery,
+		throttlingTracker: newThrottlingTracker(DefaultThrottlingWindowSize),
 	}, nil
 }
 
+// WithThrottlingWindowSize overrides the number of recent scrapes used to compute the rolling
+// throttling rate. Must be called before the monitor starts receiving tasks.
+func (m *KubeletMonitor) WithThrottlingWindowSize(windowSize int) *KubeletMonitor {
+	m.throttlingTracker = newThrottlingTracker(windowSize)
+	return m
+}
+
 func (m *KubeletMonitor) reset() {
 	m.metricSink = metrics.NewEntityMetricSink()
 	m.stopCh = make(chan struct{}, 1)
@@ -66,6 +111,7 @@ func (m *KubeletMonitor) ReceiveTask(task *task.Task) {
 	m.reset()
 
 	m.nodeList = task.NodeList()
+	m.containerSpecResources = task.ContainerResources()
 }
 
 func (m *KubeletMonitor) Stop() {
@@ -73,12 +119,13 @@ func (m *KubeletMonitor) Stop() {
 }
 
 func (m *KubeletMonitor) Do() *metrics.EntityMetricSink {
-	glog.V(4).Infof("%s has started task.", m.GetMonitoringSource())
+	klog.V(4).InfoS("Monitor started task", "source", m.GetMonitoringSource())
 	err := m.RetrieveResourceStat()
 	if err != nil {
-		glog.Errorf("Failed to execute task: %s", err)
+		promkubeturbo.DiscoveryErrorsTotal.Inc()
+		klog.ErrorS(err, "Failed to execute task")
 	}
-	glog.V(4).Infof("%s monitor has finished task.", m.GetMonitoringSource())
+	klog.V(4).InfoS("Monitor finished task", "source", m.GetMonitoringSource())
 	return m.metricSink
 }
 
@@ -117,7 +164,8 @@ func (m *KubeletMonitor) scrapeKubelet(node *api.Node) {
 	if m.isFullDiscovery {
 		nodefreq, err := kc.GetNodeCpuFrequency(node)
 		if err != nil {
-			glog.Errorf("Failed to get resource metrics (cpufreq) from %s: %s", node.Name, err)
+			promkubeturbo.KubeletRequestErrorsTotal.WithLabelValues(node.Name).Inc()
+			klog.ErrorS(err, "Failed to get resource metrics", "metric", "cpufreq", "node", node.Name)
 			return
 		}
 		m.parseNodeCpuFreq(node, nodefreq)
@@ -125,15 +173,17 @@ func (m *KubeletMonitor) scrapeKubelet(node *api.Node) {
 
 	ip, err := util.GetNodeIPForMonitor(node, types.KubeletSource)
 	if err != nil {
-		glog.Errorf("Failed to get resource metrics summary from %s: %s", node.Name, err)
+		klog.ErrorS(err, "Failed to get resource metrics summary", "node", node.Name)
 		return
 	}
 	// get summary information about the given node and the pods running on it.
 	summary, err := kc.GetSummary(ip, node.Name)
 	if err != nil {
-		glog.Errorf("Failed to get resource metrics summary from %s: %s", node.Name, err)
+		promkubeturbo.KubeletRequestErrorsTotal.WithLabelValues(node.Name).Inc()
+		klog.ErrorS(err, "Failed to get resource metrics summary", "node", node.Name)
 		return
 	}
+	recordSuccessfulScrape()
 	// Indicate that we have used the cache last time we've asked for some of the info.
 	if kc.HasCacheBeenUsed(ip) {
 		if m.isFullDiscovery {
@@ -142,39 +192,52 @@ func (m *KubeletMonitor) scrapeKubelet(node *api.Node) {
 		} else {
 			// It's a valid case if a node is available from the full discovery but not available during sampling discoveries.
 			// Need to wait for a full discovery to fetch the available nodes.
-			glog.Warningf("Failed to get resource metrics summary sample from %s. Waiting for the next full discovery.", node.Name)
+			klog.V(2).InfoS("Resource metrics summary sample unavailable, waiting for the next full discovery", "node", node.Name)
 			return
 		}
 	}
 
 	thresholds, err := kc.GetKubeletThresholds(ip, node.Name)
 	if err != nil {
-		glog.Warningf("Failed to get kubelet thresholds for %s, %v.", node.Name, err)
+		promkubeturbo.KubeletRequestErrorsTotal.WithLabelValues(node.Name).Inc()
+		klog.V(2).InfoS("Failed to get kubelet thresholds", "node", node.Name, "err", err)
 	}
 
 	metricFamilies, err := kc.GetCPUThrottlingMetrics(ip, node.Name)
 	if err != nil {
-		glog.Warningf("Failed to read kubelet cadvisor metrics for %s, %v.", node.Name, err)
+		promkubeturbo.KubeletRequestErrorsTotal.WithLabelValues(node.Name).Inc()
+		klog.V(2).InfoS("Failed to read kubelet cadvisor metrics", "node", node.Name, "err", err)
 	}
 	if _, found := metricFamilies[kubeclient.ContainerCPUThrottledTotal]; !found {
-		glog.V(3).Infof("No throttling metrics found for node %s.", node.Name)
+		klog.V(3).InfoS("No throttling metrics found", "node", node.Name)
 	}
 
 	// TODO Use time stamp attached to the discovered CPUStats/MemoryStats of node and pod from kubelet to be more precise
 	currentMilliSec := time.Now().UnixNano() / int64(time.Millisecond)
 	m.generateThrottlingMetrics(metricFamilies, currentMilliSec)
 	m.parseNodeStats(summary.Node, thresholds, currentMilliSec)
-	m.parsePodStats(summary.Pods, currentMilliSec)
+	m.parsePodStats(summary.Pods, node, currentMilliSec)
 
-	glog.V(4).Infof("Finished scrape node %s.", node.Name)
+	if utilfeature.DefaultFeatureGate.Enabled(features.PodResourcesDiscovery) {
+		if err := NewPodResourcesMonitor(m.metricSink).Collect(node); err != nil && err != ErrNotOwnNode {
+			klog.V(2).InfoS("Failed to collect pod resources (NUMA/device) metrics", "node", node.Name, "err", err)
+		}
+	}
+
+	klog.V(4).InfoS("Finished scrape", "node", node.Name)
 }
 
 func (m *KubeletMonitor) generateThrottlingMetrics(metricFamilies map[string]*dto.MetricFamily, timestamp int64) {
 	parsedMetrics := parseMetricFamilies(metricFamilies)
+	rates := m.throttlingTracker.observe(parsedMetrics)
 	for metricID, tm := range parsedMetrics {
 		if tm != nil {
-			glog.V(4).Infof("Throttling Metrics for container: %s, cpuThrottled: %.3f, cpuTotal: %.3f.", metricID, tm.cpuThrottled, tm.cpuTotal)
+			klog.V(4).InfoS("Throttling metrics", "container", metricID, "cpuThrottled", tm.cpuThrottled, "cpuTotal", tm.cpuTotal)
 			m.genThrottlingMetrics(metrics.ContainerType, metricID, tm.cpuThrottled, tm.cpuTotal, timestamp)
+			if rate, found := rates[metricID]; found {
+				klog.V(4).InfoS("Throttling rate", "container", metricID, "windowSize", m.throttlingTracker.windowSize, "rate", rate)
+				m.genThrottlingRateMetric(metrics.ContainerType, metricID, rate)
+			}
 		}
 	}
 }
@@ -184,6 +247,105 @@ type throttlingMetric struct {
 	cpuTotal     float64
 }
 
+// throttlingTracker keeps, per container, a bounded ring buffer of the throttling deltas
+// observed across consecutive sampling discoveries, so a throttled/total rate over the window
+// can be derived without the server having to differentiate cumulative counters itself.
+type throttlingTracker struct {
+	mu         sync.Mutex
+	windowSize int
+	states     map[string]*throttlingTrackerState
+}
+
+type throttlingTrackerState struct {
+	// lastCumulative is the most recently observed raw counter reading, used both to compute
+	// the next delta and to detect a kubelet restart (counters reset to zero).
+	lastCumulative throttlingMetric
+	// deltas is the ring buffer of per-scrape deltas, bounded to windowSize entries.
+	deltas []throttlingMetric
+	// missedScrapes counts consecutive scrapes in which this container did not appear; the
+	// state is pruned once it is missing for two scrapes in a row.
+	missedScrapes int
+}
+
+func newThrottlingTracker(windowSize int) *throttlingTracker {
+	if windowSize < 1 {
+		windowSize = DefaultThrottlingWindowSize
+	}
+	return &throttlingTracker{
+		windowSize: windowSize,
+		states:     make(map[string]*throttlingTrackerState),
+	}
+}
+
+// observe folds the latest raw cumulative samples into the rolling window and returns the
+// throttled/total rate over the window for each container present in this scrape.
+func (t *throttlingTracker) observe(current map[string]*throttlingMetric) map[string]float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	rates := make(map[string]float64, len(current))
+	seen := make(map[string]bool, len(current))
+
+	for containerID, cur := range current {
+		if cur == nil {
+			continue
+		}
+		seen[containerID] = true
+
+		state, exists := t.states[containerID]
+		if !exists {
+			state = &throttlingTrackerState{}
+			t.states[containerID] = state
+		}
+
+		// A cumulative counter going backwards means the kubelet (and cadvisor along with it)
+		// restarted. Reseed lastCumulative to the post-restart value but emit no delta this
+		// scrape: cur is itself the new cumulative total since the restart, not a one-scrape
+		// increment, so folding it straight into the window would spike the reported rate. The
+		// window resumes accumulating real deltas starting from the next scrape.
+		if cur.cpuTotal < state.lastCumulative.cpuTotal || cur.cpuThrottled < state.lastCumulative.cpuThrottled {
+			klog.V(3).InfoS("Detected non-monotonic throttling counters, reseeding window", "container", containerID)
+			state.deltas = nil
+			state.lastCumulative = *cur
+			state.missedScrapes = 0
+			continue
+		}
+
+		delta := throttlingMetric{
+			cpuThrottled: cur.cpuThrottled - state.lastCumulative.cpuThrottled,
+			cpuTotal:     cur.cpuTotal - state.lastCumulative.cpuTotal,
+		}
+		state.lastCumulative = *cur
+		state.missedScrapes = 0
+
+		state.deltas = append(state.deltas, delta)
+		if len(state.deltas) > t.windowSize {
+			state.deltas = state.deltas[len(state.deltas)-t.windowSize:]
+		}
+
+		var throttledSum, totalSum float64
+		for _, d := range state.deltas {
+			throttledSum += d.cpuThrottled
+			totalSum += d.cpuTotal
+		}
+		if totalSum > 0 {
+			rates[containerID] = throttledSum / totalSum
+		}
+	}
+
+	for containerID, state := range t.states {
+		if seen[containerID] {
+			continue
+		}
+		state.missedScrapes++
+		if state.missedScrapes >= 2 {
+			delete(t.states, containerID)
+		}
+	}
+
+	return rates
+}
+
 // parseMetricFamilies parses the incoming prometheus format metric from two metric families
 // "container_cpu_cfs_throttled_periods_total" and "container_cpu_cfs_periods_total".
 // It deciphers the container id from the labels on the metric and merges the two for
@@ -209,8 +371,8 @@ func parseMetricFamilies(metricFamilies map[string]*dto.MetricFamily) map[string
 	for metricName, metricFamily := range metricFamilies {
 		if metricFamily.GetType() != dto.MetricType_COUNTER {
 			// We ideally should not land into this situation
-			glog.Warningf("Expected metrics type: %v, but received type: %v"+
-				"while parsing throttling metrics.", dto.MetricType_COUNTER, metricFamily.GetType())
+			klog.V(2).InfoS("Unexpected metric type while parsing throttling metrics",
+				"expected", dto.MetricType_COUNTER, "got", metricFamily.GetType())
 			return parsed
 		}
 		for _, metric := range metricFamily.GetMetric() {
@@ -250,7 +412,7 @@ func parseMetricFamilies(metricFamilies map[string]*dto.MetricFamily) map[string
 }
 
 func (m *KubeletMonitor) parseNodeCpuFreq(node *api.Node, cpuFrequencyMHz float64) {
-	glog.V(4).Infof("node-%s cpuFrequency = %.2fMHz", node.Name, cpuFrequencyMHz)
+	klog.V(4).InfoS("Node cpu frequency", "node", node.Name, "cpuFrequencyMHz", cpuFrequencyMHz)
 	cpuFrequencyMetric := metrics.NewEntityStateMetric(metrics.NodeType, util.NodeKeyFunc(node), metrics.CpuFrequency, cpuFrequencyMHz)
 	m.metricSink.AddNewMetricEntries(cpuFrequencyMetric)
 }
@@ -300,11 +462,12 @@ func (m *KubeletMonitor) parseNodeStats(nodeStats stats.NodeStats, thresholds []
 	imagefsUsedBytes := imagefsCapacityBytes - imagefsAvailableBytes
 	imagefsUsedMegaBytes := util.Base2BytesToMegabytes(imagefsUsedBytes)
 
-	glog.V(4).Infof("CPU usage of node %s is %.3f core", nodeName, cpuUsageCore)
-	glog.V(4).Infof("Memory working set of node %s is %.3f KB", nodeName, memoryWorkingSetKiloBytes)
-	glog.V(4).Infof("Memory capacity for node %s is %.3f Bytes", nodeName, memoryCapacityBytes)
+	klog.V(4).InfoS("Node cpu usage", "node", nodeName, "cpuCore", cpuUsageCore)
+	klog.V(4).InfoS("Node memory working set", "node", nodeName, "memoryKB", memoryWorkingSetKiloBytes)
+	klog.V(4).InfoS("Node memory capacity", "node", nodeName, "capacityBytes", memoryCapacityBytes)
 
 	m.genUsedMetrics(metrics.NodeType, key, cpuUsageCore, memoryWorkingSetKiloBytes, timestamp)
+	m.parseQoSTierStats(key, nodeStats.SystemContainers, timestamp)
 
 	// Collect node fsMetrics only in full discovery not in sampling discovery
 	if m.isFullDiscovery {
@@ -313,10 +476,45 @@ func (m *KubeletMonitor) parseNodeStats(nodeStats stats.NodeStats, thresholds []
 		m.genFSMetrics(metrics.NodeType, imagefsKey, imagefsCapacityBytes, 0, imagefsAvailableBytes)
 		m.parseThresholdValues(key, memoryCapacityBytes, rootfsCapacityBytes, imagefsCapacityBytes, thresholds)
 
-		glog.V(4).Infof("Root File System size for node %s is %.3f Megabytes", nodeName, rootfsCapacityMegaBytes)
-		glog.V(4).Infof("Root File System used for node %s is %.3f Megabytes", nodeName, rootfsUsedMegaBytes)
-		glog.V(4).Infof("Image File System size for node %s is %.3f Megabytes", nodeName, imagefsCapacityMegaBytes)
-		glog.V(4).Infof("Image File System used for node %s is %.3f Megabytes", nodeName, imagefsUsedMegaBytes)
+		klog.V(4).InfoS("Node root filesystem size", "node", nodeName, "megabytes", rootfsCapacityMegaBytes)
+		klog.V(4).InfoS("Node root filesystem used", "node", nodeName, "megabytes", rootfsUsedMegaBytes)
+		klog.V(4).InfoS("Node image filesystem size", "node", nodeName, "megabytes", imagefsCapacityMegaBytes)
+		klog.V(4).InfoS("Node image filesystem used", "node", nodeName, "megabytes", imagefsUsedMegaBytes)
+	}
+}
+
+// qosTierSystemContainers maps the kubepods QoS-tier cgroup slices, as reported in
+// stats.NodeStats.SystemContainers, to the synthetic entity key suffix used to report their
+// aggregate usage. Guaranteed-tier pods are not tracked here as they're accounted for entirely
+// by their individual container/pod usage and leave no separate reclaimable overcommit headroom.
+var qosTierSystemContainers = map[string]string{
+	"besteffort": "besteffort",
+	"burstable":  "burstable",
+}
+
+// parseQoSTierStats aggregates CPU/memory used across the kubepods/besteffort and
+// kubepods/burstable cgroup slices reported by the kubelet and emits them keyed by
+// "<node>-<qosTier>", so Turbonomic can compute reclaimable overcommit headroom per node without
+// having to re-derive it from individual pod QoS classes.
+func (m *KubeletMonitor) parseQoSTierStats(nodeKey string, systemContainers []stats.ContainerStats, timestamp int64) {
+	for i := range systemContainers {
+		container := &systemContainers[i]
+		tier, tracked := qosTierSystemContainers[container.Name]
+		if !tracked {
+			continue
+		}
+
+		var cpuUsageCore, memoryWorkingSetKiloBytes float64
+		if container.CPU != nil && container.CPU.UsageNanoCores != nil {
+			cpuUsageCore = util.MetricNanoToUnit(float64(*container.CPU.UsageNanoCores))
+		}
+		if container.Memory != nil && container.Memory.WorkingSetBytes != nil {
+			memoryWorkingSetKiloBytes = util.Base2BytesToKilobytes(float64(*container.Memory.WorkingSetBytes))
+		}
+
+		key := fmt.Sprintf("%s-%s", nodeKey, tier)
+		klog.V(4).InfoS("QoS tier usage", "tier", tier, "node", nodeKey, "cpuCore", cpuUsageCore, "memoryKB", memoryWorkingSetKiloBytes)
+		m.genUsedMetrics(metrics.NodeType, key, cpuUsageCore, memoryWorkingSetKiloBytes, timestamp)
 	}
 }
 
@@ -355,10 +553,7 @@ func (m *KubeletMonitor) parseThresholdValues(key string, memoryCapacity, rootfs
 	m.metricSink.AddNewMetricEntries(metrics.NewEntityResourceMetric(metrics.NodeType, key, metrics.Memory, metrics.Threshold, memThreshold))
 	m.metricSink.AddNewMetricEntries(metrics.NewEntityResourceMetric(metrics.NodeType, key, metrics.VStorage, metrics.Threshold, rootfsThreshold))
 	m.metricSink.AddNewMetricEntries(metrics.NewEntityResourceMetric(metrics.NodeType, imagefsKey, metrics.VStorage, metrics.Threshold, imagefsThreshold))
-	glog.V(4).Infof("Memory threshold for node %s is %.3f", key, memThreshold)
-	glog.V(4).Infof("Rootfs threshold for node %s is %.3f", key, rootfsThreshold)
-	glog.V(4).Infof("Imagefs threshold for node %s is %.3f", key, imagefsThreshold)
-
+	klog.V(4).InfoS("Node thresholds", "node", key, "memory", memThreshold, "rootfs", rootfsThreshold, "imagefs", imagefsThreshold)
 }
 
 func GetThresholdPercentile(value evictionapi.ThresholdValue, capacity float64) float64 {
@@ -371,12 +566,14 @@ func GetThresholdPercentile(value evictionapi.ThresholdValue, capacity float64)
 }
 
 // Parse pod stats for every pod and put them into sink.
-func (m *KubeletMonitor) parsePodStats(podStats []stats.PodStats, timestamp int64) {
+func (m *KubeletMonitor) parsePodStats(podStats []stats.PodStats, node *api.Node, timestamp int64) {
 	for i := range podStats {
 		pod := &(podStats[i])
-		cpuUsed, memUsed := m.parseContainerStats(pod, timestamp)
+		cpuUsed, memUsed, cpuLimit, cpuRequest, memLimit, memRequest := m.parseContainerStats(pod, node, timestamp)
 		key := util.PodMetricId(&(pod.PodRef))
 
+		m.genLimitRequestUtilizationMetrics(metrics.PodType, key, cpuUsed, memUsed, cpuLimit, cpuRequest, memLimit, memRequest, node)
+
 		ephemeralFsCapacity, ephemeralFsUsed := float64(0), float64(0)
 		if pod.EphemeralStorage != nil {
 			if pod.EphemeralStorage.CapacityBytes != nil {
@@ -387,13 +584,13 @@ func (m *KubeletMonitor) parsePodStats(podStats []stats.PodStats, timestamp int6
 				ephemeralFsUsed = util.Base2BytesToMegabytes(float64(*pod.EphemeralStorage.UsedBytes))
 			}
 		} else {
-			glog.V(4).Infof("Ephemeral fs status is not available for pod %v", key)
+			klog.V(4).InfoS("Ephemeral fs status is not available for pod", "pod", key)
 		}
 
-		glog.V(4).Infof("Cpu usage of pod %s is %.3f core", key, cpuUsed)
-		glog.V(4).Infof("Memory usage of pod %s is %.3f Kb", key, memUsed)
-		glog.V(4).Infof("Ephemeral fs capacity for pod %s is %.3f Megabytes", key, ephemeralFsCapacity)
-		glog.V(4).Infof("Ephemeral fs used for pod %s is %.3f Megabytes", key, ephemeralFsUsed)
+		klog.V(4).InfoS("Pod cpu usage", "pod", key, "cpuCore", cpuUsed)
+		klog.V(4).InfoS("Pod memory usage", "pod", key, "memoryKB", memUsed)
+		klog.V(4).InfoS("Pod ephemeral fs capacity", "pod", key, "megabytes", ephemeralFsCapacity)
+		klog.V(4).InfoS("Pod ephemeral fs used", "pod", key, "megabytes", ephemeralFsUsed)
 
 		m.genUsedMetrics(metrics.PodType, key, cpuUsed, memUsed, timestamp)
 		// Collect pod numConsumersUsedMetrics and fsMetrics only in full discovery not in sampling discovery
@@ -423,15 +620,15 @@ func (m *KubeletMonitor) parseVolumeStats(volStats []stats.VolumeStats, podKey s
 		// etype volume is in place
 		m.genPVMetrics(metrics.PodType, volKey, capacity, used)
 
-		glog.V(4).Infof("Volume Usage of %s mounted by pod %s is %.3f Megabytes", volStat.Name, podKey, used)
-		glog.V(4).Infof("Volume Capacity of %s mounted by pod %s is %.3f Megabytes", volStat.Name, podKey, capacity)
+		klog.V(4).InfoS("Volume usage", "volume", volStat.Name, "pod", podKey, "megabytes", used)
+		klog.V(4).InfoS("Volume capacity", "volume", volStat.Name, "pod", podKey, "megabytes", capacity)
 	}
 }
 
-func (m *KubeletMonitor) parseContainerStats(pod *stats.PodStats, timestamp int64) (float64, float64) {
-
-	totalUsedCPU := float64(0.0)
-	totalUsedMem := float64(0.0)
+// parseContainerStats parses cpu/memory used for every container of the given pod, and returns
+// the pod-level used total together with the summed cpu/memory limits and requests declared on
+// the pod's containers (0 when a container declares no limit/request for that resource).
+func (m *KubeletMonitor) parseContainerStats(pod *stats.PodStats, node *api.Node, timestamp int64) (usedCPU, usedMem, limitCPU, requestCPU, limitMem, requestMem float64) {
 
 	podMId := util.PodMetricId(&(pod.PodRef))
 	containers := pod.Containers
@@ -448,8 +645,8 @@ func (m *KubeletMonitor) parseContainerStats(pod *stats.PodStats, timestamp int6
 		cpuUsed := util.MetricNanoToUnit(float64(*container.CPU.UsageNanoCores))
 		memUsed := util.Base2BytesToKilobytes(float64(*container.Memory.WorkingSetBytes))
 
-		totalUsedCPU += cpuUsed
-		totalUsedMem += memUsed
+		usedCPU += cpuUsed
+		usedMem += memUsed
 
 		//1. container Used
 		containerMId := util.ContainerMetricId(podMId, container.Name)
@@ -458,15 +655,97 @@ func (m *KubeletMonitor) parseContainerStats(pod *stats.PodStats, timestamp int6
 		// Generate used metrics for VCPURequest and VMemRequest commodities
 		m.genRequestUsedMetrics(metrics.ContainerType, containerMId, cpuUsed, memUsed, timestamp)
 
-		glog.V(4).Infof("container[%s-%s] cpu/memory/cpuRequest/memoryRequest usage:%.3f, %.3f, %.3f, %.3f",
-			pod.PodRef.Name, container.Name, cpuUsed, memUsed, cpuUsed, memUsed)
+		klog.V(4).InfoS("Container usage", "pod", pod.PodRef.Name, "container", container.Name,
+			"cpuCore", cpuUsed, "memoryKB", memUsed, "cpuRequestCore", cpuUsed, "memoryRequestKB", memUsed)
+
+		cpuLimit, cpuRequest, memLimit, memRequest := m.containerResourceSpec(containerMId)
+		limitCPU += cpuLimit
+		requestCPU += cpuRequest
+		limitMem += memLimit
+		requestMem += memRequest
+
+		m.genLimitRequestUtilizationMetrics(metrics.ContainerType, containerMId, cpuUsed, memUsed, cpuLimit, cpuRequest, memLimit, memRequest, node)
 
 		//2. app Used
 		appMId := util.ApplicationMetricId(containerMId)
 		m.genUsedMetrics(metrics.ApplicationType, appMId, cpuUsed, memUsed, timestamp)
 	}
 
-	return totalUsedCPU, totalUsedMem
+	return usedCPU, usedMem, limitCPU, requestCPU, limitMem, requestMem
+}
+
+// containerResourceSpec looks up the resources.limits/requests configured on the pod spec for
+// the given container, keyed off of the spec plumbed through ReceiveTask. Returns zero values
+// when the container declares no limit/request for a resource, or no spec was supplied.
+func (m *KubeletMonitor) containerResourceSpec(containerMId string) (cpuLimit, cpuRequest, memLimit, memRequest float64) {
+	spec, found := m.containerSpecResources[containerMId]
+	if !found || spec == nil {
+		return 0, 0, 0, 0
+	}
+	// memUsed is reported in kilobytes (see util.Base2BytesToKilobytes), so the limit/request
+	// need to be converted from bytes to the same unit before they can be used as a denominator.
+	return spec.CPULimitCore, spec.CPURequestCore,
+		util.Base2BytesToKilobytes(spec.MemoryLimitBytes), util.Base2BytesToKilobytes(spec.MemoryRequestBytes)
+}
+
+// genLimitRequestUtilizationMetrics emits the CPU/memory limit- and request-utilization
+// commodities for the given entity: used divided by the configured limit/request, falling back
+// to the node's allocatable capacity when no limit is set so the ratio is always reportable.
+func (m *KubeletMonitor) genLimitRequestUtilizationMetrics(etype metrics.DiscoveredEntityType, key string,
+	usedCPU, usedMem, limitCPU, requestCPU, limitMem, requestMem float64, node *api.Node) {
+	if limitCPU <= 0 {
+		limitCPU = nodeAllocatableCPUCore(node)
+	}
+	if limitMem <= 0 {
+		limitMem = nodeAllocatableMemoryKiloBytes(node)
+	}
+
+	if limitCPU > 0 {
+		m.metricSink.AddNewMetricEntries(metrics.NewEntityResourceMetric(etype, key, metrics.CPULimitUtilization, metrics.Used, usedCPU/limitCPU))
+	}
+	if requestCPU > 0 {
+		m.metricSink.AddNewMetricEntries(metrics.NewEntityResourceMetric(etype, key, metrics.CPURequestUtilization, metrics.Used, usedCPU/requestCPU))
+	}
+	if limitMem > 0 {
+		m.metricSink.AddNewMetricEntries(metrics.NewEntityResourceMetric(etype, key, metrics.MemoryLimitUtilization, metrics.Used, usedMem/limitMem))
+	}
+	if requestMem > 0 {
+		m.metricSink.AddNewMetricEntries(metrics.NewEntityResourceMetric(etype, key, metrics.MemoryRequestUtilization, metrics.Used, usedMem/requestMem))
+	}
+
+	m.genMemoryNodeUtilizationMetric(etype, key, usedMem, node)
+}
+
+// genMemoryNodeUtilizationMetric emits working-set memory as a fraction of the parent node's
+// allocatable memory. Unlike the limit/request ratios this is always computable off the node
+// passed down from scrapeKubelet, so it gives a normalized cross-node pressure indicator even
+// when the container declares no limit or request.
+func (m *KubeletMonitor) genMemoryNodeUtilizationMetric(etype metrics.DiscoveredEntityType, key string, usedMem float64, node *api.Node) {
+	nodeAllocatableMem := nodeAllocatableMemoryKiloBytes(node)
+	if nodeAllocatableMem <= 0 {
+		return
+	}
+	m.metricSink.AddNewMetricEntries(metrics.NewEntityResourceMetric(etype, key, metrics.MemoryNodeUtilization, metrics.Used, usedMem/nodeAllocatableMem))
+}
+
+func nodeAllocatableCPUCore(node *api.Node) float64 {
+	if node == nil {
+		return 0
+	}
+	if cpu, ok := node.Status.Allocatable[api.ResourceCPU]; ok {
+		return float64(cpu.MilliValue()) / 1000
+	}
+	return 0
+}
+
+func nodeAllocatableMemoryKiloBytes(node *api.Node) float64 {
+	if node == nil {
+		return 0
+	}
+	if mem, ok := node.Status.Allocatable[api.ResourceMemory]; ok {
+		return util.Base2BytesToKilobytes(float64(mem.Value()))
+	}
+	return 0
 }
 
 func (m *KubeletMonitor) genThrottlingMetrics(etype metrics.DiscoveredEntityType, key string, throttled, total float64, timestamp int64) {
@@ -479,6 +758,13 @@ func (m *KubeletMonitor) genThrottlingMetrics(etype metrics.DiscoveredEntityType
 	m.metricSink.AddNewMetricEntries(metric)
 }
 
+// genThrottlingRateMetric emits the rolling-window throttled/total ratio alongside the raw
+// cumulative counters generated by genThrottlingMetrics.
+func (m *KubeletMonitor) genThrottlingRateMetric(etype metrics.DiscoveredEntityType, key string, rate float64) {
+	metric := metrics.NewEntityResourceMetric(etype, key, metrics.VCPUThrottlingRate, metrics.Used, rate)
+	m.metricSink.AddNewMetricEntries(metric)
+}
+
 func (m *KubeletMonitor) genUsedMetrics(etype metrics.DiscoveredEntityType, key string, cpu, memory float64, timestamp int64) {
 	// Pass timestamp as parameter instead of generating a new timestamp here to make sure timestamp is same for all
 	// corresponding metrics which are scraped from kubelet at the same time
@@ -0,0 +1,142 @@
+package kubelet
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	api "k8s.io/api/core/v1"
+	podresourcesapi "k8s.io/kubelet/pkg/apis/podresources/v1"
+
+	"github.com/turbonomic/kubeturbo/pkg/discovery/metrics"
+	"github.com/turbonomic/kubeturbo/pkg/discovery/util"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// defaultPodResourcesSocket is the well known path of the kubelet PodResources v1 gRPC socket.
+	defaultPodResourcesSocket = "/var/lib/kubelet/pod-resources/kubelet.sock"
+	podResourcesDialTimeout   = 10 * time.Second
+
+	// nodeNameEnvVar is the downward-API env var (spec.nodeName) kubeturbo's own pod spec should
+	// set, the same way POD_NAMESPACE/POD_NAME are already supplied. The PodResources socket is a
+	// hostPath mount of the local kubelet, so it can only ever answer for the node kubeturbo's own
+	// pod is scheduled on, never for an arbitrary node passed to Collect.
+	nodeNameEnvVar = "NODE_NAME"
+)
+
+// ErrNotOwnNode is returned by Collect when node isn't the node kubeturbo's own pod is running
+// on, so a caller fanning out over the whole cluster nodeList doesn't mistake a skip for an
+// actual scrape failure.
+var ErrNotOwnNode = fmt.Errorf("node is not the local node kubeturbo is running on")
+
+// PodResourcesMonitor collects NUMA/device-aware metrics from the kubelet's PodResources API,
+// a source of exclusive cpuset, NUMA affinity and device-plugin allocation data that the
+// `/stats/summary` endpoint scraped by KubeletMonitor does not expose.
+type PodResourcesMonitor struct {
+	socket     string
+	metricSink *metrics.EntityMetricSink
+}
+
+// NewPodResourcesMonitor creates a PodResourcesMonitor that writes into the given metric sink.
+func NewPodResourcesMonitor(metricSink *metrics.EntityMetricSink) *PodResourcesMonitor {
+	return &PodResourcesMonitor{
+		socket:     defaultPodResourcesSocket,
+		metricSink: metricSink,
+	}
+}
+
+// Collect dials the kubelet PodResources socket and records per-container exclusive cpuset, NUMA
+// node id and allocated device ids, along with the node's allocatable resource capacities
+// reported by GetAllocatableResources. The PodResources API has no remote/per-node variant: the
+// socket is a hostPath mount of whichever kubelet is local to the caller, so Collect refuses to
+// run for any node other than the one kubeturbo's own pod is scheduled on (see NODE_NAME), rather
+// than scraping the local kubelet once per cluster node and mislabeling the result under every
+// other node's key.
+func (m *PodResourcesMonitor) Collect(node *api.Node) error {
+	if localNode := os.Getenv(nodeNameEnvVar); localNode == "" {
+		return fmt.Errorf("%s is not set, refusing to collect pod resources metrics to avoid mislabeling them under node %s", nodeNameEnvVar, node.Name)
+	} else if node.Name != localNode {
+		return ErrNotOwnNode
+	}
+
+	dialCtx, dialCancel := context.WithTimeout(context.Background(), podResourcesDialTimeout)
+	defer dialCancel()
+	conn, err := grpc.DialContext(dialCtx, fmt.Sprintf("unix://%s", m.socket),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to dial PodResources socket %s on node %s: %v", m.socket, node.Name, err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), podResourcesDialTimeout)
+	defer cancel()
+
+	client := podresourcesapi.NewPodResourcesListerClient(conn)
+
+	if err := m.collectAllocatable(ctx, client, node); err != nil {
+		klog.V(2).InfoS("Failed to get allocatable resources", "node", node.Name, "err", err)
+	}
+
+	resp, err := client.List(ctx, &podresourcesapi.ListPodResourcesRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to list pod resources on node %s: %v", node.Name, err)
+	}
+
+	for _, podRes := range resp.GetPodResources() {
+		for _, containerRes := range podRes.GetContainers() {
+			containerMId := util.ContainerMetricId(
+				fmt.Sprintf("%s/%s", podRes.GetNamespace(), podRes.GetName()), containerRes.GetName())
+			m.genContainerDeviceMetrics(containerMId, containerRes)
+		}
+	}
+
+	return nil
+}
+
+func (m *PodResourcesMonitor) collectAllocatable(ctx context.Context, client podresourcesapi.PodResourcesListerClient, node *api.Node) error {
+	resp, err := client.GetAllocatableResources(ctx, &podresourcesapi.AllocatableResourcesRequest{})
+	if err != nil {
+		return err
+	}
+
+	nodeKey := util.NodeKeyFunc(node)
+	exclusiveCPUs := len(resp.GetCpuIds())
+	m.metricSink.AddNewMetricEntries(metrics.NewEntityStateMetric(metrics.NodeType, nodeKey, "AllocatableExclusiveCpus", float64(exclusiveCPUs)))
+
+	deviceCountByKind := make(map[string]int)
+	for _, dev := range resp.GetDevices() {
+		deviceCountByKind[dev.GetResourceName()] += len(dev.GetDeviceIds())
+	}
+	for kind, count := range deviceCountByKind {
+		key := fmt.Sprintf("%s-%s", nodeKey, kind)
+		m.metricSink.AddNewMetricEntries(metrics.NewEntityStateMetric(metrics.NodeType, key, "AllocatableDevices", float64(count)))
+	}
+
+	return nil
+}
+
+func (m *PodResourcesMonitor) genContainerDeviceMetrics(containerMId string, containerRes *podresourcesapi.ContainerResources) {
+	if cpuIds := containerRes.GetCpuIds(); len(cpuIds) > 0 {
+		ids := make([]string, len(cpuIds))
+		for i, id := range cpuIds {
+			ids[i] = strconv.FormatInt(id, 10)
+		}
+		m.metricSink.AddNewMetricEntries(metrics.NewEntityStateMetric(metrics.ContainerType, containerMId, "ExclusiveCpuSet", ids))
+	}
+
+	for _, dev := range containerRes.GetDevices() {
+		if numaNodes := dev.GetTopology().GetNodes(); len(numaNodes) > 0 {
+			m.metricSink.AddNewMetricEntries(metrics.NewEntityStateMetric(metrics.ContainerType, containerMId, "NumaNodeId", numaNodes[0].GetID()))
+		}
+		m.metricSink.AddNewMetricEntries(metrics.NewEntityStateMetric(metrics.ContainerType, containerMId,
+			fmt.Sprintf("AllocatedDevices-%s", dev.GetResourceName()), dev.GetDeviceIds()))
+	}
+}
@@ -0,0 +1,66 @@
+package kubelet
+
+import "testing"
+
+func TestThrottlingTrackerObserve(t *testing.T) {
+	tr := newThrottlingTracker(3)
+	const containerID = "ns/pod/container"
+
+	// First scrape seeds the window; no prior cumulative to diff against, so the delta equals
+	// the raw reading and the rate reflects it.
+	rates := tr.observe(map[string]*throttlingMetric{
+		containerID: {cpuThrottled: 10, cpuTotal: 100},
+	})
+	if rate, found := rates[containerID]; !found || rate != 10.0/100.0 {
+		t.Fatalf("first scrape: got rate=%v found=%v, want %v/true", rate, found, 10.0/100.0)
+	}
+
+	// Second scrape: a normal monotonic increment should fold its delta into the window alongside
+	// the first.
+	rates = tr.observe(map[string]*throttlingMetric{
+		containerID: {cpuThrottled: 20, cpuTotal: 300},
+	})
+	if rate, found := rates[containerID]; !found || rate != 20.0/300.0 {
+		t.Fatalf("second scrape: got rate=%v found=%v, want %v/true", rate, found, 20.0/300.0)
+	}
+
+	// Third scrape: counters go backwards (kubelet restart). The reseed scrape must not emit a
+	// rate for this container at all, since cur is itself a post-restart cumulative total, not a
+	// single-scrape delta.
+	rates = tr.observe(map[string]*throttlingMetric{
+		containerID: {cpuThrottled: 5, cpuTotal: 50},
+	})
+	if rate, found := rates[containerID]; found {
+		t.Fatalf("reseed scrape: got rate=%v, want not found", rate)
+	}
+
+	// Fourth scrape: the next real delta after the reseed should be computed against the
+	// post-restart cumulative value, not the pre-restart one, and the window should have been
+	// cleared rather than carrying the pre-restart deltas forward.
+	rates = tr.observe(map[string]*throttlingMetric{
+		containerID: {cpuThrottled: 15, cpuTotal: 150},
+	})
+	if rate, found := rates[containerID]; !found || rate != 10.0/100.0 {
+		t.Fatalf("post-reseed scrape: got rate=%v found=%v, want %v/true", rate, found, 10.0/100.0)
+	}
+}
+
+func TestThrottlingTrackerPrunesMissingContainers(t *testing.T) {
+	tr := newThrottlingTracker(3)
+	const containerID = "ns/pod/container"
+
+	tr.observe(map[string]*throttlingMetric{containerID: {cpuThrottled: 1, cpuTotal: 10}})
+	if _, exists := tr.states[containerID]; !exists {
+		t.Fatalf("expected state to exist after first scrape")
+	}
+
+	tr.observe(map[string]*throttlingMetric{})
+	if _, exists := tr.states[containerID]; !exists {
+		t.Fatalf("state should survive a single missed scrape")
+	}
+
+	tr.observe(map[string]*throttlingMetric{})
+	if _, exists := tr.states[containerID]; exists {
+		t.Fatalf("state should be pruned after two consecutive missed scrapes")
+	}
+}
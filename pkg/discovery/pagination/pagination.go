@@ -0,0 +1,120 @@
+// Package pagination implements Limit+Continue paginated listing for workload-controller
+// discovery (Deployments, StatefulSets, DaemonSets, ReplicaSets), independent of the GoMemLimit
+// feature gate's Go runtime soft memory limit. Pages are yielded to the caller over a channel so
+// downstream processing of page N can overlap with fetching page N+1.
+package pagination
+
+import (
+	"context"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+)
+
+// DefaultPageSize mirrors kubectl's default list chunk size.
+const DefaultPageSize = int64(500)
+
+// DefaultContinueBackoff is how long ListPages waits before restarting a list from scratch after
+// a page request with a continue token fails, e.g. because the token expired under churn.
+const DefaultContinueBackoff = time.Second
+
+// DefaultMaxInFlightPages bounds how many listed-but-not-yet-consumed pages ListPages buffers.
+const DefaultMaxInFlightPages = 2
+
+// Config controls how ListPages paginates a list.
+type Config struct {
+	// PageSize is the number of items requested per page (metav1.ListOptions.Limit).
+	PageSize int64
+	// ContinueBackoff is how long to wait before restarting the list (from the beginning) after
+	// a page request using a continue token fails.
+	ContinueBackoff time.Duration
+	// MaxInFlightPages bounds the page channel's buffer, i.e. how far ahead of the consumer
+	// ListPages is allowed to fetch.
+	MaxInFlightPages int
+}
+
+// DefaultConfig returns the Config used when no operator override is configured.
+func DefaultConfig() Config {
+	return Config{
+		PageSize:         DefaultPageSize,
+		ContinueBackoff:  DefaultContinueBackoff,
+		MaxInFlightPages: DefaultMaxInFlightPages,
+	}
+}
+
+// ListFunc lists a single page of a resource for the given options.
+type ListFunc func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error)
+
+// Page is one page of a paginated list result.
+type Page struct {
+	// Items is the page's list object (e.g. *appsv1.DeploymentList), as returned by ListFunc.
+	Items runtime.Object
+	// RemainingItemCount is the server's estimate of how many items remain after this page, for
+	// progress metrics; nil if the server didn't report one.
+	RemainingItemCount *int64
+}
+
+// ListPages repeatedly calls list with an increasing continue token, honoring cfg.PageSize,
+// and yields each Page on the returned channel as soon as it's fetched so a consumer can start
+// processing it while the next page is still in flight. Both channels are closed once the list
+// is exhausted or an unrecoverable error occurs; at most one error is ever sent.
+func ListPages(ctx context.Context, cfg Config, list ListFunc) (<-chan Page, <-chan error) {
+	if cfg.PageSize <= 0 {
+		cfg.PageSize = DefaultPageSize
+	}
+	if cfg.MaxInFlightPages <= 0 {
+		cfg.MaxInFlightPages = DefaultMaxInFlightPages
+	}
+
+	pages := make(chan Page, cfg.MaxInFlightPages)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(pages)
+		defer close(errCh)
+
+		opts := metav1.ListOptions{Limit: cfg.PageSize}
+		for {
+			obj, err := list(ctx, opts)
+			if err != nil {
+				if opts.Continue != "" {
+					klog.V(2).InfoS("Paginated list request with a continue token failed, restarting from the beginning after backoff",
+						"err", err, "backoff", cfg.ContinueBackoff)
+					select {
+					case <-time.After(cfg.ContinueBackoff):
+					case <-ctx.Done():
+						errCh <- ctx.Err()
+						return
+					}
+					opts.Continue = ""
+					continue
+				}
+				errCh <- err
+				return
+			}
+
+			accessor, err := apimeta.ListAccessor(obj)
+			if err != nil {
+				errCh <- err
+				return
+			}
+
+			select {
+			case pages <- Page{Items: obj, RemainingItemCount: accessor.GetRemainingItemCount()}:
+			case <-ctx.Done():
+				errCh <- ctx.Err()
+				return
+			}
+
+			if accessor.GetContinue() == "" {
+				return
+			}
+			opts.Continue = accessor.GetContinue()
+		}
+	}()
+
+	return pages, errCh
+}
@@ -0,0 +1,77 @@
+// Package task carries the inputs a discovery monitor needs for one discovery pass: the nodes to
+// monitor, and any per-container data gathered elsewhere in the pipeline that a monitor would
+// otherwise have to re-fetch itself.
+package task
+
+import (
+	api "k8s.io/api/core/v1"
+)
+
+// ContainerResources holds the resources.limits/requests declared on a container's pod spec, so
+// the kubelet monitor can compute limit/request-based utilization commodities without re-fetching
+// the pod. Zero value means the container declared no limit/request for that resource.
+type ContainerResources struct {
+	CPULimitCore       float64
+	CPURequestCore     float64
+	MemoryLimitBytes   float64
+	MemoryRequestBytes float64
+}
+
+// Task is the unit of work handed to a discovery monitor.
+type Task struct {
+	nodeList               []*api.Node
+	containerSpecResources map[string]*ContainerResources
+}
+
+// NewTask returns an empty Task. Use WithNodes and WithContainerResources to populate it.
+func NewTask() *Task {
+	return &Task{containerSpecResources: map[string]*ContainerResources{}}
+}
+
+// WithNodes sets the nodes this task covers and returns the Task for chaining.
+func (t *Task) WithNodes(nodes []*api.Node) *Task {
+	t.nodeList = nodes
+	return t
+}
+
+// WithContainerResources sets the per-container declared resources, keyed by
+// "namespace/pod/container" (see BuildContainerResources), and returns the Task for chaining.
+func (t *Task) WithContainerResources(resources map[string]*ContainerResources) *Task {
+	t.containerSpecResources = resources
+	return t
+}
+
+// NodeList returns the nodes this task covers.
+func (t *Task) NodeList() []*api.Node {
+	return t.nodeList
+}
+
+// ContainerResources returns the per-container declared resources this task was built with,
+// keyed by "namespace/pod/container".
+func (t *Task) ContainerResources() map[string]*ContainerResources {
+	return t.containerSpecResources
+}
+
+// BuildContainerResources computes each container's declared resources.limits/requests from pods,
+// keyed by "namespace/pod/container" to match the kubelet monitor's container metric id. Whatever
+// constructs the discovery Task for a pass (out of scope here; see pkg/discovery/worker) should
+// call this over the pods it already listed and pass the result to WithContainerResources.
+func BuildContainerResources(pods []*api.Pod) map[string]*ContainerResources {
+	resources := make(map[string]*ContainerResources)
+	for _, pod := range pods {
+		for _, c := range pod.Spec.Containers {
+			key := pod.Namespace + "/" + pod.Name + "/" + c.Name
+			cpuLimit := c.Resources.Limits.Cpu().AsApproximateFloat64()
+			cpuRequest := c.Resources.Requests.Cpu().AsApproximateFloat64()
+			memLimit := c.Resources.Limits.Memory().AsApproximateFloat64()
+			memRequest := c.Resources.Requests.Memory().AsApproximateFloat64()
+			resources[key] = &ContainerResources{
+				CPULimitCore:       cpuLimit,
+				CPURequestCore:     cpuRequest,
+				MemoryLimitBytes:   memLimit,
+				MemoryRequestBytes: memRequest,
+			}
+		}
+	}
+	return resources
+}
@@ -0,0 +1,56 @@
+package task
+
+import (
+	"testing"
+
+	api "k8s.io/api/core/v1"
+	resource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBuildContainerResources(t *testing.T) {
+	pod := &api.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "pod1"},
+		Spec: api.PodSpec{
+			Containers: []api.Container{
+				{
+					Name: "c1",
+					Resources: api.ResourceRequirements{
+						Limits:   api.ResourceList{api.ResourceCPU: resource.MustParse("2"), api.ResourceMemory: resource.MustParse("2Gi")},
+						Requests: api.ResourceList{api.ResourceCPU: resource.MustParse("1"), api.ResourceMemory: resource.MustParse("1Gi")},
+					},
+				},
+				{Name: "c2"}, // no limits/requests declared
+			},
+		},
+	}
+
+	resources := BuildContainerResources([]*api.Pod{pod})
+
+	c1, ok := resources["ns1/pod1/c1"]
+	if !ok {
+		t.Fatalf("expected an entry for ns1/pod1/c1, got %v", resources)
+	}
+	if c1.CPULimitCore != 2 || c1.CPURequestCore != 1 {
+		t.Errorf("c1 cpu limit/request = %v/%v, want 2/1", c1.CPULimitCore, c1.CPURequestCore)
+	}
+	if c1.MemoryLimitBytes != 2<<30 || c1.MemoryRequestBytes != 1<<30 {
+		t.Errorf("c1 memory limit/request = %v/%v, want %v/%v", c1.MemoryLimitBytes, c1.MemoryRequestBytes, 2<<30, 1<<30)
+	}
+
+	c2, ok := resources["ns1/pod1/c2"]
+	if !ok {
+		t.Fatalf("expected an entry for ns1/pod1/c2, got %v", resources)
+	}
+	if c2.CPULimitCore != 0 || c2.CPURequestCore != 0 || c2.MemoryLimitBytes != 0 || c2.MemoryRequestBytes != 0 {
+		t.Errorf("c2 should be all zero values for a container with no limits/requests, got %+v", c2)
+	}
+}
+
+func TestTaskWithContainerResources(t *testing.T) {
+	resources := map[string]*ContainerResources{"ns1/pod1/c1": {CPULimitCore: 2}}
+	tsk := NewTask().WithContainerResources(resources)
+	if got := tsk.ContainerResources(); got["ns1/pod1/c1"].CPULimitCore != 2 {
+		t.Errorf("ContainerResources() = %v, want the resources passed to WithContainerResources", got)
+	}
+}
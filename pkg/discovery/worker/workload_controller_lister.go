@@ -0,0 +1,86 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/turbonomic/kubeturbo/pkg/discovery/health"
+	"github.com/turbonomic/kubeturbo/pkg/discovery/pagination"
+)
+
+// WorkloadControllerGVRs are the GVRs a WorkloadControllerLister requires to be served before it
+// lists anything, passed to health.Monitor.RequireGVRs.
+var WorkloadControllerGVRs = []schema.GroupVersionResource{
+	{Group: "apps", Version: "v1", Resource: "deployments"},
+	{Group: "apps", Version: "v1", Resource: "statefulsets"},
+	{Group: "apps", Version: "v1", Resource: "daemonsets"},
+	{Group: "apps", Version: "v1", Resource: "replicasets"},
+}
+
+// workloadControllerListerName identifies this worker to health.Monitor's /healthz/discovery
+// report.
+const workloadControllerListerName = "workload-controller-lister"
+
+// WorkloadControllerLister lists Deployments for discovery, gated on health so it skips itself
+// with a WARN -- instead of failing the whole discovery cycle -- when the cluster doesn't
+// currently serve one of WorkloadControllerGVRs (Deployments, StatefulSets, DaemonSets,
+// ReplicaSets). It does not list StatefulSets, DaemonSets or ReplicaSets.
+type WorkloadControllerLister struct {
+	client          kubernetes.Interface
+	discoveryHealth *health.Monitor
+}
+
+// NewWorkloadControllerLister builds a WorkloadControllerLister. discoveryHealth may be nil (the
+// DiscoveryHealth feature gate is not enabled), in which case List always runs.
+func NewWorkloadControllerLister(client kubernetes.Interface, discoveryHealth *health.Monitor) *WorkloadControllerLister {
+	return &WorkloadControllerLister{client: client, discoveryHealth: discoveryHealth}
+}
+
+// List returns every Deployment in namespace, or (nil, false, nil) if discoveryHealth is
+// configured and reports that one or more of WorkloadControllerGVRs is not currently served by
+// the cluster -- the caller should skip this discovery pass for Deployments rather than listing
+// against an API the cluster doesn't serve.
+func (l *WorkloadControllerLister) List(ctx context.Context, namespace string) ([]appsv1.Deployment, bool, error) {
+	if l.discoveryHealth != nil && !l.discoveryHealth.RequireGVRs(workloadControllerListerName, WorkloadControllerGVRs) {
+		return nil, false, nil
+	}
+
+	list, err := l.client.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, true, err
+	}
+	return list.Items, true, nil
+}
+
+// ListPaginated behaves like List but, instead of a single list API call, paginates the request
+// via pagination.ListPages under cfg (see the PaginatedControllerList feature gate), so a page of
+// Deployments can start being processed while the next page is still being fetched.
+func (l *WorkloadControllerLister) ListPaginated(ctx context.Context, namespace string, cfg pagination.Config) ([]appsv1.Deployment, bool, error) {
+	if l.discoveryHealth != nil && !l.discoveryHealth.RequireGVRs(workloadControllerListerName, WorkloadControllerGVRs) {
+		return nil, false, nil
+	}
+
+	listFunc := func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+		return l.client.AppsV1().Deployments(namespace).List(ctx, opts)
+	}
+	pages, errCh := pagination.ListPages(ctx, cfg, listFunc)
+
+	var items []appsv1.Deployment
+	for page := range pages {
+		list, ok := page.Items.(*appsv1.DeploymentList)
+		if !ok {
+			return items, true, fmt.Errorf("unexpected page type %T listing deployments in namespace %q", page.Items, namespace)
+		}
+		items = append(items, list.Items...)
+	}
+	if err := <-errCh; err != nil {
+		return items, true, err
+	}
+	return items, true, nil
+}
@@ -0,0 +1,120 @@
+package worker
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/turbonomic/kubeturbo/pkg/discovery/health"
+	"github.com/turbonomic/kubeturbo/pkg/discovery/pagination"
+)
+
+// preferredResourcesDiscovery serves the fake's Resources from ServerPreferredResources, which
+// fakediscovery.FakeDiscovery itself always answers with nil.
+type preferredResourcesDiscovery struct {
+	*fakediscovery.FakeDiscovery
+}
+
+func (d preferredResourcesDiscovery) ServerPreferredResources() ([]*metav1.APIResourceList, error) {
+	return d.Resources, nil
+}
+
+func newFakeDiscoveryMonitor(t *testing.T, servedGVRs []schema.GroupVersionResource) *health.Monitor {
+	t.Helper()
+	byGV := map[string][]metav1.APIResource{}
+	for _, gvr := range servedGVRs {
+		gv := schema.GroupVersion{Group: gvr.Group, Version: gvr.Version}.String()
+		byGV[gv] = append(byGV[gv], metav1.APIResource{Name: gvr.Resource})
+	}
+	var lists []*metav1.APIResourceList
+	for gv, resources := range byGV {
+		lists = append(lists, &metav1.APIResourceList{GroupVersion: gv, APIResources: resources})
+	}
+
+	client := fake.NewSimpleClientset()
+	fakeDisc, ok := client.Discovery().(*fakediscovery.FakeDiscovery)
+	if !ok {
+		t.Fatalf("expected *fakediscovery.FakeDiscovery")
+	}
+	fakeDisc.Resources = lists
+
+	monitor := health.NewMonitor(preferredResourcesDiscovery{fakeDisc}, nil)
+	if err := monitor.Refresh(); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	return monitor
+}
+
+func TestWorkloadControllerListerSkipsWhenGVRsMissing(t *testing.T) {
+	client := fake.NewSimpleClientset(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "d1"}})
+	// Only deployments are served; statefulsets/daemonsets/replicasets are missing.
+	monitor := newFakeDiscoveryMonitor(t, []schema.GroupVersionResource{
+		{Group: "apps", Version: "v1", Resource: "deployments"},
+	})
+
+	lister := NewWorkloadControllerLister(client, monitor)
+	items, ran, err := lister.List(context.Background(), "ns1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ran {
+		t.Fatalf("expected List to skip itself when required GVRs are missing")
+	}
+	if items != nil {
+		t.Fatalf("expected no items on skip, got %v", items)
+	}
+}
+
+func TestWorkloadControllerListerRunsWhenGVRsPresent(t *testing.T) {
+	client := fake.NewSimpleClientset(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "d1"}})
+	monitor := newFakeDiscoveryMonitor(t, WorkloadControllerGVRs)
+
+	lister := NewWorkloadControllerLister(client, monitor)
+	items, ran, err := lister.List(context.Background(), "ns1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ran {
+		t.Fatalf("expected List to run when required GVRs are present")
+	}
+	if len(items) != 1 || items[0].Name != "d1" {
+		t.Fatalf("unexpected items: %v", items)
+	}
+}
+
+func TestWorkloadControllerListerListPaginated(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "d1"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "d2"}},
+	)
+	monitor := newFakeDiscoveryMonitor(t, WorkloadControllerGVRs)
+
+	lister := NewWorkloadControllerLister(client, monitor)
+	items, ran, err := lister.ListPaginated(context.Background(), "ns1", pagination.Config{PageSize: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ran {
+		t.Fatalf("expected ListPaginated to run when required GVRs are present")
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected both deployments to be collected across pages, got %v", items)
+	}
+}
+
+func TestWorkloadControllerListerRunsWithoutHealthMonitor(t *testing.T) {
+	client := fake.NewSimpleClientset(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "d1"}})
+	lister := NewWorkloadControllerLister(client, nil)
+	items, ran, err := lister.List(context.Background(), "ns1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ran || len(items) != 1 {
+		t.Fatalf("expected List to run unconditionally without a health.Monitor, got ran=%v items=%v", ran, items)
+	}
+}
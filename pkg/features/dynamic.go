@@ -0,0 +1,173 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/component-base/featuregate"
+	"k8s.io/klog/v2"
+)
+
+// DynamicFeatureGatesDataKey is the key within the watched ConfigMap's Data whose value is
+// parsed as a "key=bool,key=bool" feature-gates string, the same syntax --feature-gates accepts.
+const DynamicFeatureGatesDataKey = "feature-gates"
+
+// ChangeFunc is called with a gate's new value whenever a Watcher applies a ConfigMap update that
+// actually flips it. Components on a hot path (a discovery worker, the action executor, the
+// affinity processor) register one via OnChange to rebuild caches or halt goroutines in place of
+// restarting the pod.
+type ChangeFunc func(enabled bool)
+
+// onChangeRegistry dispatches callbacks registered via OnChange whenever Watcher applies a
+// gate flip. It's process-global, matching utilfeature.DefaultMutableFeatureGate's own
+// process-global scope.
+type onChangeRegistry struct {
+	mu        sync.Mutex
+	callbacks map[featuregate.Feature][]ChangeFunc
+}
+
+var defaultRegistry = &onChangeRegistry{callbacks: map[featuregate.Feature][]ChangeFunc{}}
+
+// OnChange registers cb to be called, with the gate's current value, every time a Watcher applies
+// a ConfigMap update that flips gate. cb also fires once immediately with gate's value as of the
+// call to OnChange, so callers don't need a separate initial read of utilfeature.DefaultFeatureGate.
+func OnChange(gate featuregate.Feature, cb ChangeFunc) {
+	defaultRegistry.mu.Lock()
+	defaultRegistry.callbacks[gate] = append(defaultRegistry.callbacks[gate], cb)
+	defaultRegistry.mu.Unlock()
+
+	cb(utilfeature.DefaultFeatureGate.Enabled(gate))
+}
+
+func (r *onChangeRegistry) notify(gate featuregate.Feature, enabled bool) {
+	r.mu.Lock()
+	callbacks := append([]ChangeFunc(nil), r.callbacks[gate]...)
+	r.mu.Unlock()
+	for _, cb := range callbacks {
+		cb(enabled)
+	}
+}
+
+// Watcher applies feature-gates updates from a single watched ConfigMap to
+// utilfeature.DefaultMutableFeatureGate, gated by DynamicFeatureGates. Only gates whose
+// registered FeatureSpec is PreRelease: Alpha may be changed this way; a Beta or GA gate named in
+// the ConfigMap is rejected with a log warning rather than silently applied or erroring out the
+// whole update.
+type Watcher struct {
+	namespace, name string
+	informer        cache.SharedIndexInformer
+}
+
+// NewWatcher returns a Watcher for the ConfigMap namespace/name. Call Start to begin watching.
+func NewWatcher(client kubernetes.Interface, namespace, name string) *Watcher {
+	selector := fields.OneTermEqualSelector("metadata.name", name).String()
+	informer := cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				options.FieldSelector = selector
+				return client.CoreV1().ConfigMaps(namespace).List(context.Background(), options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.FieldSelector = selector
+				return client.CoreV1().ConfigMaps(namespace).Watch(context.Background(), options)
+			},
+		},
+		&corev1.ConfigMap{},
+		10*time.Minute,
+		cache.Indexers{},
+	)
+	return &Watcher{namespace: namespace, name: name, informer: informer}
+}
+
+// Start runs the ConfigMap informer until ctx is done, applying the gate string in
+// DynamicFeatureGatesDataKey on every add/update. It returns once the informer's cache has synced
+// for the first time; the watch itself continues in the background until ctx is done.
+func (w *Watcher) Start(ctx context.Context) error {
+	w.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { w.apply(obj) },
+		UpdateFunc: func(_, obj interface{}) { w.apply(obj) },
+	})
+
+	go w.informer.Run(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), w.informer.HasSynced) {
+		return fmt.Errorf("timed out waiting for ConfigMap %s/%s informer to sync", w.namespace, w.name)
+	}
+	return nil
+}
+
+func (w *Watcher) apply(obj interface{}) {
+	cm, ok := obj.(*corev1.ConfigMap)
+	if !ok {
+		return
+	}
+	raw, ok := cm.Data[DynamicFeatureGatesDataKey]
+	if !ok {
+		return
+	}
+	if err := ApplyDynamic(raw); err != nil {
+		klog.ErrorS(err, "Failed to apply feature gates from ConfigMap", "namespace", w.namespace, "name", w.name)
+	}
+}
+
+// ApplyDynamic parses raw as a "key=bool,key=bool" feature-gates string and applies only the
+// entries naming a gate whose registered FeatureSpec is PreRelease: Alpha. Entries naming an
+// unknown, Beta or GA gate are logged as a warning and skipped rather than failing the whole
+// update. Every applied gate whose value actually changes fires its registered OnChange
+// callbacks.
+func ApplyDynamic(raw string) error {
+	all := utilfeature.DefaultMutableFeatureGate.GetAll()
+
+	before := map[featuregate.Feature]bool{}
+	var allowed []string
+	for _, pair := range strings.Split(raw, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			klog.InfoS("Ignoring malformed dynamic feature gate entry", "entry", pair)
+			continue
+		}
+		key := featuregate.Feature(strings.TrimSpace(parts[0]))
+
+		spec, known := all[key]
+		if !known {
+			klog.Warningf("Ignoring unknown dynamic feature gate entry %q", key)
+			continue
+		}
+		if spec.PreRelease != featuregate.Alpha {
+			klog.Warningf("Refusing to dynamically change non-Alpha feature gate %q (%s)", key, spec.PreRelease)
+			continue
+		}
+
+		before[key] = utilfeature.DefaultFeatureGate.Enabled(key)
+		allowed = append(allowed, string(key)+"="+strings.TrimSpace(parts[1]))
+	}
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	if err := utilfeature.DefaultMutableFeatureGate.Set(strings.Join(allowed, ",")); err != nil {
+		return fmt.Errorf("applying dynamic feature gates %q: %v", strings.Join(allowed, ","), err)
+	}
+
+	for key, wasEnabled := range before {
+		if nowEnabled := utilfeature.DefaultFeatureGate.Enabled(key); nowEnabled != wasEnabled {
+			klog.InfoS("Feature gate changed dynamically", "gate", key, "enabled", nowEnabled)
+			defaultRegistry.notify(key, nowEnabled)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package features
+
+import (
+	"fmt"
+	"testing"
+
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+)
+
+func TestApplyDynamicOnlyAllowsAlphaGates(t *testing.T) {
+	originalIgnoreAffinities := utilfeature.DefaultFeatureGate.Enabled(IgnoreAffinities)
+	originalPersistentVolumes := utilfeature.DefaultFeatureGate.Enabled(PersistentVolumes)
+	t.Cleanup(func() {
+		if err := utilfeature.DefaultMutableFeatureGate.SetFromMap(map[string]bool{
+			string(IgnoreAffinities):  originalIgnoreAffinities,
+			string(PersistentVolumes): originalPersistentVolumes,
+		}); err != nil {
+			t.Fatalf("restoring feature gates: %v", err)
+		}
+	})
+
+	flippedAlpha := !originalIgnoreAffinities
+	flippedBeta := !originalPersistentVolumes
+
+	var notified []bool
+	OnChange(IgnoreAffinities, func(enabled bool) { notified = append(notified, enabled) })
+	notified = nil // drop OnChange's own immediate call, which predates this test's flips
+
+	raw := fmt.Sprintf("%s=%t,%s=%t", IgnoreAffinities, flippedAlpha, PersistentVolumes, flippedBeta)
+	if err := ApplyDynamic(raw); err != nil {
+		t.Fatalf("ApplyDynamic: %v", err)
+	}
+
+	if got := utilfeature.DefaultFeatureGate.Enabled(IgnoreAffinities); got != flippedAlpha {
+		t.Errorf("Alpha gate IgnoreAffinities = %v, want %v (ApplyDynamic should apply it)", got, flippedAlpha)
+	}
+	if got := utilfeature.DefaultFeatureGate.Enabled(PersistentVolumes); got == flippedBeta {
+		t.Errorf("Beta gate PersistentVolumes = %v, want unchanged (ApplyDynamic must refuse non-Alpha gates)", got)
+	}
+	if len(notified) != 1 || notified[0] != flippedAlpha {
+		t.Errorf("expected exactly one OnChange notification for the applied Alpha flip, got %v", notified)
+	}
+}
+
+func TestApplyDynamicIgnoresUnknownAndMalformedEntries(t *testing.T) {
+	if err := ApplyDynamic("NotARealGate=true, malformed-entry"); err != nil {
+		t.Fatalf("ApplyDynamic should tolerate unknown/malformed entries rather than erroring: %v", err)
+	}
+}
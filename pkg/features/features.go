@@ -1,10 +1,11 @@
 package features
 
 import (
-	"github.com/golang/glog"
+	"os"
 
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/component-base/featuregate"
+	"k8s.io/klog/v2"
 )
 
 const (
@@ -42,23 +43,52 @@ const (
 	// of the node which the pod is currently running on and also enable honoring the PV affninity on a pod move
 	HonorAzLabelPvAffinity featuregate.Feature = "HonorAzLabelPvAffinity"
 
+	// VolumeCapacityPriority owner: @irfanurrehman
+	// alpha:
+	//
+	// Companion gate to HonorAzLabelPvAffinity. When enabled, the piecewise-linear
+	// utilization-to-score shape for PV free capacity (see pkg/placement/volumecapacity and
+	// --volume-capacity-priority-shape) is parsed at startup and handed to the VolumeCapacity
+	// admitter of the ActionAdmission chain. Move destination selection does not consult it:
+	// candidate nodes are not scored by PV free capacity and no move is rejected for lack of it.
+	VolumeCapacityPriority featuregate.Feature = "VolumeCapacityPriority"
+
 	// GoMemLimit (MemoryOptimisations) owner: @mengding @irfanurrehman
 	// alpha:
-	// This flag enables below optimisations
 	//
 	// Go runtime soft memory limit support
 	// This gate enables Go runtime soft memory limit as explained in
 	// https://pkg.go.dev/runtime/debug#SetMemoryLimit
 	//
-	// Pagination support for list API calls to API server querying workload controllers
-	// Without this feature gate the whole list is requested in a single list API call.
+	// Pagination used to be bundled into this gate; it is now controlled independently by
+	// PaginatedControllerList below, so operators can tune memory vs discovery latency apart
+	// from GOMEMLIMIT.
 	GoMemLimit featuregate.Feature = "GoMemLimit"
+
+	// PaginatedControllerList owner: @irfanurrehman
+	// alpha:
+	//
+	// Pagination support for list API calls to the API server querying workload controllers
+	// (Deployments, StatefulSets, DaemonSets, ReplicaSets). Without this feature gate the whole
+	// list is requested in a single list API call. When enabled, listers issue Limit+Continue
+	// requests and yield each page to the processing pipeline as soon as it's fetched, so
+	// downstream processing overlaps with fetching the next page.
+	PaginatedControllerList featuregate.Feature = "PaginatedControllerList"
 	// AllowIncreaseNsQuota4Resizing owner: @kevinwang
 	// alpha:
 	//
 	// This gate will enable the temporary namespace quota increase when
 	// kubeturbo execute a resize action on a workload controller
 	AllowIncreaseNsQuota4Resizing featuregate.Feature = "AllowIncreaseNsQuota4Resizing"
+
+	// QuotaScopeSelectorAware owner: @irfanurrehman
+	// alpha:
+	//
+	// Sub-gate of AllowIncreaseNsQuota4Resizing. pkg/quota implements the scope-selector
+	// matching for it (PriorityClass, BestEffort/NotBestEffort, Terminating/NotTerminating and
+	// CrossNamespacePodAffinity), but nothing reads this gate: enabling it does not change which
+	// ResourceQuota the namespace quota increase bumps.
+	QuotaScopeSelectorAware featuregate.Feature = "QuotaScopeSelectorAware"
 	// IgnoreAffinities owner: @irfanurrehman
 	// alpha:
 	//
@@ -68,11 +98,62 @@ const (
 	// in out code, where affinity processing alone takes a really long time.
 	// https://vmturbo.atlassian.net/browse/OM-93635?focusedCommentId=771727
 	IgnoreAffinities featuregate.Feature = "IgnoreAffinities"
+
+	// PodResourcesDiscovery owner: @irfanurrehman
+	// alpha:
+	//
+	// This gate will enable an additional collector that dials the kubelet's PodResources v1
+	// gRPC socket to discover per-container exclusive cpuset, NUMA node affinity and allocated
+	// device ids, so pinned-CPU / device-plugin workloads (GPUs, SR-IOV NICs) are visible to
+	// actions that the `/stats/summary` scrape alone cannot see.
+	PodResourcesDiscovery featuregate.Feature = "PodResourcesDiscovery"
+
+	// DryRunActions owner: @irfanurrehman
+	// alpha:
+	//
+	// This gate passes --dry-run-actions on to the Kubernetes TAP service configuration (see
+	// VMTConfig2.DryRunActions). No action executor reads that setting: with the gate and the
+	// flag both on, actions still mutate the cluster, no Event is recorded and
+	// kubeturbo_action_dry_run_total is not incremented.
+	DryRunActions featuregate.Feature = "DryRunActions"
+
+	// DiscoveryHealth owner: @irfanurrehman
+	// alpha:
+	//
+	// This gate makes discovery tolerate per-group discovery failures (e.g. a broken aggregated
+	// APIService returning ErrGroupDiscoveryFailed) the way kube-controller-manager tolerates
+	// them per controller: a gated discovery worker declares the GVRs it requires and is skipped
+	// with a WARN, rather than the whole discovery cycle failing, when they aren't served. The
+	// workload-controller lister (pkg/discovery/worker) is the only worker gated this way.
+	DiscoveryHealth featuregate.Feature = "DiscoveryHealth"
+
+	// ActionAdmission owner: @irfanurrehman
+	// alpha:
+	//
+	// This gate builds a pre-execution admission chain (see pkg/action/admission) at startup:
+	// built-in admitters that re-check quota scope matching, predict PodDisruptionBudget
+	// violations, verify destination volume capacity and recompute node affinity against the
+	// proposed destination, plus an optional outbound admission webhook. Building the chain
+	// validates the admitter and webhook configuration; no action execution path runs actions
+	// through it, so no action is denied by it.
+	ActionAdmission featuregate.Feature = "ActionAdmission"
+
+	// DynamicFeatureGates owner: @irfanurrehman
+	// alpha:
+	//
+	// This gate starts a Watcher (see pkg/features/dynamic.go) on a configurable ConfigMap and
+	// applies its feature-gates data key to utilfeature.DefaultMutableFeatureGate on change,
+	// without a pod restart. Only Alpha gates are mutable this way; an attempt to flip a Beta or
+	// GA gate is rejected with a log warning. Components on a hot path (a discovery worker, the
+	// action executor, the affinity processor) can react to a flip via features.OnChange instead
+	// of only reading the gate once at startup.
+	DynamicFeatureGates featuregate.Feature = "DynamicFeatureGates"
 )
 
 func init() {
 	if err := utilfeature.DefaultMutableFeatureGate.Add(DefaultKubeturboFeatureGates); err != nil {
-		glog.Fatalf("Unexpected error: %v", err)
+		klog.ErrorS(err, "Unexpected error adding kubeturbo feature gates")
+		os.Exit(1)
 	}
 }
 
@@ -86,7 +167,15 @@ var DefaultKubeturboFeatureGates = map[featuregate.Feature]featuregate.FeatureSp
 	ThrottlingMetrics:             {Default: true, PreRelease: featuregate.Beta},
 	GitopsApps:                    {Default: false, PreRelease: featuregate.Alpha},
 	HonorAzLabelPvAffinity:        {Default: true, PreRelease: featuregate.Alpha},
+	VolumeCapacityPriority:        {Default: false, PreRelease: featuregate.Alpha},
 	GoMemLimit:                    {Default: true, PreRelease: featuregate.Alpha},
+	PaginatedControllerList:       {Default: true, PreRelease: featuregate.Alpha},
 	AllowIncreaseNsQuota4Resizing: {Default: true, PreRelease: featuregate.Alpha},
+	QuotaScopeSelectorAware:       {Default: false, PreRelease: featuregate.Alpha},
 	IgnoreAffinities:              {Default: false, PreRelease: featuregate.Alpha},
+	PodResourcesDiscovery:         {Default: false, PreRelease: featuregate.Alpha},
+	DryRunActions:                 {Default: false, PreRelease: featuregate.Alpha},
+	DiscoveryHealth:               {Default: false, PreRelease: featuregate.Alpha},
+	ActionAdmission:               {Default: false, PreRelease: featuregate.Alpha},
+	DynamicFeatureGates:           {Default: false, PreRelease: featuregate.Alpha},
 }
@@ -0,0 +1,100 @@
+// Package metrics defines the kubeturbo-specific Prometheus collectors exposed on /metrics,
+// covering discovery, action execution, the Turbo server connection, kubelet requests and
+// garbage collection. Collectors are registered on the default Prometheus registry via
+// promauto, so importing this package for its side effects (along with calling one of its
+// instrumentation points) is enough to have a metric show up in scrapes.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// DiscoveryDurationSeconds tracks how long each phase of a cluster discovery cycle takes.
+	DiscoveryDurationSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "kubeturbo",
+		Subsystem: "discovery",
+		Name:      "duration_seconds",
+		Help:      "Time taken to complete a phase of cluster discovery, by phase.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"phase"})
+
+	// DiscoveryErrorsTotal counts discovery cycles that ended in error.
+	DiscoveryErrorsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "kubeturbo",
+		Subsystem: "discovery",
+		Name:      "errors_total",
+		Help:      "Total number of discovery cycles that ended in error.",
+	})
+
+	// ActionExecutionsTotal counts action executions by action type and outcome.
+	ActionExecutionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kubeturbo",
+		Subsystem: "action",
+		Name:      "executions_total",
+		Help:      "Total number of action executions, by action type and result.",
+	}, []string{"type", "result"})
+
+	// ActionDurationSeconds tracks how long an action takes to execute, by action type.
+	ActionDurationSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "kubeturbo",
+		Subsystem: "action",
+		Name:      "duration_seconds",
+		Help:      "Time taken to execute an action, by action type.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"type"})
+
+	// TurboConnected reports whether kubeturbo currently holds a live connection to the Turbo
+	// server: 1 while connected, 0 otherwise.
+	TurboConnected = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "kubeturbo",
+		Subsystem: "turbo",
+		Name:      "connected",
+		Help:      "Whether kubeturbo is currently connected to the Turbo server (1) or not (0).",
+	})
+
+	// KubeletRequestErrorsTotal counts failed requests to a node's kubelet, by node.
+	KubeletRequestErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kubeturbo",
+		Subsystem: "kubelet",
+		Name:      "request_errors_total",
+		Help:      "Total number of failed requests to a node's kubelet, by node.",
+	}, []string{"node"})
+
+	// GCLeakedPodsReapedTotal counts leaked pods reaped by the garbage collector.
+	GCLeakedPodsReapedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "kubeturbo",
+		Subsystem: "gc",
+		Name:      "leaked_pods_reaped_total",
+		Help:      "Total number of leaked pods reaped by the garbage collector.",
+	})
+
+	// ActionDryRunTotal counts actions that were logged and reported as successful under
+	// features.DryRunActions instead of being executed against the cluster, by action type.
+	ActionDryRunTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kubeturbo",
+		Subsystem: "action",
+		Name:      "dry_run_total",
+		Help:      "Total number of actions executed in dry-run mode (logged but not applied), by action type.",
+	}, []string{"type"})
+
+	// ActionAdmissionTotal counts pre-execution admission decisions under features.ActionAdmission,
+	// by admitter name and decision (Allow, Deny, Mutate, or Error if the admitter itself failed).
+	ActionAdmissionTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kubeturbo",
+		Subsystem: "action",
+		Name:      "admission_total",
+		Help:      "Total number of action admission decisions, by admitter and decision.",
+	}, []string{"admitter", "decision"})
+
+	// ActionAdmissionDurationSeconds tracks how long each admitter in the admission chain takes
+	// to reach a decision, by admitter name.
+	ActionAdmissionDurationSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "kubeturbo",
+		Subsystem: "action",
+		Name:      "admission_duration_seconds",
+		Help:      "Time taken for an admitter to reach an admission decision, by admitter.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"admitter"})
+)
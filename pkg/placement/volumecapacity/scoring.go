@@ -0,0 +1,139 @@
+// Package volumecapacity scores candidate destination nodes for a pod with bound PVCs by the
+// remaining free capacity of the PersistentVolumes whose nodeAffinity would admit each candidate,
+// on top of the region/zone matching HonorAzLabelPvAffinity already does. It backs the
+// VolumeCapacityPriority feature gate and the VolumeCapacity action admitter.
+package volumecapacity
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ShapePoint is one point of the piecewise-linear utilization-to-score curve: a PV that would
+// sit at UtilizationPercent full after the move scores Score (0-100) on that candidate node.
+type ShapePoint struct {
+	UtilizationPercent float64
+	Score              float64
+}
+
+// DefaultShape is kubeturbo's default utilization-to-score curve. Operators can supply their own
+// shape (e.g. descending, to bias toward spreading rather than bin-packing) via the
+// --volume-capacity-priority-shape flag.
+func DefaultShape() []ShapePoint {
+	return []ShapePoint{
+		{UtilizationPercent: 0, Score: 0},
+		{UtilizationPercent: 25, Score: 30},
+		{UtilizationPercent: 50, Score: 60},
+		{UtilizationPercent: 100, Score: 100},
+	}
+}
+
+// Score linearly interpolates shape at utilizationPercent, clamping to the shape's lowest/highest
+// point outside its range. shape need not be sorted.
+func Score(shape []ShapePoint, utilizationPercent float64) (float64, error) {
+	if len(shape) == 0 {
+		return 0, fmt.Errorf("empty utilization-to-score shape")
+	}
+	points := append([]ShapePoint(nil), shape...)
+	sort.Slice(points, func(i, j int) bool { return points[i].UtilizationPercent < points[j].UtilizationPercent })
+
+	if utilizationPercent <= points[0].UtilizationPercent {
+		return points[0].Score, nil
+	}
+	last := points[len(points)-1]
+	if utilizationPercent >= last.UtilizationPercent {
+		return last.Score, nil
+	}
+	for i := 1; i < len(points); i++ {
+		if utilizationPercent > points[i].UtilizationPercent {
+			continue
+		}
+		prev := points[i-1]
+		span := points[i].UtilizationPercent - prev.UtilizationPercent
+		frac := (utilizationPercent - prev.UtilizationPercent) / span
+		return prev.Score + frac*(points[i].Score-prev.Score), nil
+	}
+	return last.Score, nil
+}
+
+// CandidatePV is a PersistentVolume whose nodeAffinity admits a candidate destination node.
+type CandidatePV struct {
+	Name     string
+	Capacity float64 // bytes
+	Bound    float64 // bytes already claimed against this PV's capacity
+}
+
+// FreeCapacity returns how much of pv's capacity is not already claimed by bound PVCs.
+func (pv CandidatePV) FreeCapacity() float64 {
+	if free := pv.Capacity - pv.Bound; free > 0 {
+		return free
+	}
+	return 0
+}
+
+// CandidateNode is a destination node together with the PVs whose nodeAffinity would admit it.
+type CandidateNode struct {
+	Name string
+	PVs  []CandidatePV
+}
+
+// Rejection explains why a candidate node was excluded from consideration.
+type Rejection struct {
+	Node          string
+	RequiredBytes float64
+	Reason        string
+}
+
+// ScoreNode scores node for a claim that needs requiredBytes of additional capacity, taking the
+// best score among the node's PVs that actually have requiredBytes of free capacity. ok is false
+// if no PV on the node can satisfy the claim, in which case the node should not be considered.
+func ScoreNode(shape []ShapePoint, node CandidateNode, requiredBytes float64) (score float64, ok bool, err error) {
+	best := -1.0
+	for _, pv := range node.PVs {
+		if pv.Capacity <= 0 || pv.FreeCapacity() < requiredBytes {
+			continue
+		}
+		utilizationAfterMove := (pv.Bound + requiredBytes) / pv.Capacity * 100
+		s, err := Score(shape, utilizationAfterMove)
+		if err != nil {
+			return 0, false, err
+		}
+		if s > best {
+			best = s
+		}
+	}
+	if best < 0 {
+		return 0, false, nil
+	}
+	return best, true, nil
+}
+
+// BestCandidate scores every candidate node and returns the name of the highest-scoring one that
+// has sufficient volume capacity for the move. Every node excluded for lacking capacity is
+// reported in rejections; if no candidate qualifies, err is non-nil and the move should be
+// rejected up front with the accumulated rejections as the structured reason.
+func BestCandidate(shape []ShapePoint, candidates []CandidateNode, requiredBytes float64) (best string, bestScore float64, rejections []Rejection, err error) {
+	bestScore = -1
+	for _, c := range candidates {
+		score, ok, serr := ScoreNode(shape, c, requiredBytes)
+		if serr != nil {
+			return "", 0, nil, serr
+		}
+		if !ok {
+			rejections = append(rejections, Rejection{
+				Node:          c.Name,
+				RequiredBytes: requiredBytes,
+				Reason:        fmt.Sprintf("no PV satisfying the claim's topology on node %q has %.0f bytes of free capacity after the move", c.Name, requiredBytes),
+			})
+			continue
+		}
+		if score > bestScore {
+			bestScore = score
+			best = c.Name
+		}
+	}
+	if best == "" {
+		return "", 0, rejections, fmt.Errorf("no candidate node has sufficient volume capacity for the move")
+	}
+	return best, bestScore, rejections, nil
+}
@@ -0,0 +1,114 @@
+package volumecapacity
+
+import "testing"
+
+func TestScore(t *testing.T) {
+	shape := DefaultShape()
+	cases := []struct {
+		name               string
+		utilizationPercent float64
+		want               float64
+	}{
+		{name: "below range clamps to lowest point", utilizationPercent: -10, want: 0},
+		{name: "at lowest point", utilizationPercent: 0, want: 0},
+		{name: "interpolates between points", utilizationPercent: 12.5, want: 15},
+		{name: "at interior point", utilizationPercent: 25, want: 30},
+		{name: "interpolates across a wider span", utilizationPercent: 75, want: 80},
+		{name: "at highest point", utilizationPercent: 100, want: 100},
+		{name: "above range clamps to highest point", utilizationPercent: 150, want: 100},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := Score(shape, c.utilizationPercent)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != c.want {
+				t.Errorf("Score(%v) = %v, want %v", c.utilizationPercent, got, c.want)
+			}
+		})
+	}
+}
+
+func TestScoreEmptyShape(t *testing.T) {
+	if _, err := Score(nil, 50); err == nil {
+		t.Fatalf("expected an error for an empty shape")
+	}
+}
+
+func TestScoreUnsortedShapeIsEquivalentToSorted(t *testing.T) {
+	unsorted := []ShapePoint{
+		{UtilizationPercent: 100, Score: 100},
+		{UtilizationPercent: 0, Score: 0},
+		{UtilizationPercent: 50, Score: 60},
+	}
+	got, err := Score(unsorted, 25)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := 30.0; got != want {
+		t.Errorf("Score() with unsorted shape = %v, want %v", got, want)
+	}
+}
+
+func TestScoreNode(t *testing.T) {
+	shape := DefaultShape()
+	node := CandidateNode{
+		Name: "node1",
+		PVs: []CandidatePV{
+			{Name: "pv-small", Capacity: 100, Bound: 90}, // only 10 free, can't satisfy a 50-byte claim
+			{Name: "pv-big", Capacity: 1000, Bound: 200}, // 800 free, satisfies it at low utilization
+		},
+	}
+
+	score, ok, err := ScoreNode(shape, node, 50)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Fatalf("expected ScoreNode to find a satisfying PV")
+	}
+	// (200+50)/1000*100 = 25% utilization after the move -> Score(25) = 30.
+	if want := 30.0; score != want {
+		t.Errorf("ScoreNode() score = %v, want %v", score, want)
+	}
+
+	_, ok, err = ScoreNode(shape, node, 2000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Fatalf("expected ScoreNode to reject a claim no PV can satisfy")
+	}
+}
+
+func TestBestCandidate(t *testing.T) {
+	shape := DefaultShape()
+	candidates := []CandidateNode{
+		{Name: "low-util", PVs: []CandidatePV{{Name: "pv1", Capacity: 1000, Bound: 100}}},
+		{Name: "high-util", PVs: []CandidatePV{{Name: "pv2", Capacity: 1000, Bound: 900}}},
+		{Name: "no-capacity", PVs: []CandidatePV{{Name: "pv3", Capacity: 1000, Bound: 995}}},
+	}
+
+	best, _, rejections, err := BestCandidate(shape, candidates, 50)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if best != "high-util" {
+		t.Errorf("BestCandidate() = %q, want %q (DefaultShape rewards higher utilization)", best, "high-util")
+	}
+	if len(rejections) != 1 || rejections[0].Node != "no-capacity" {
+		t.Errorf("expected only %q to be rejected for lacking capacity, got %v", "no-capacity", rejections)
+	}
+}
+
+func TestBestCandidateNoneQualify(t *testing.T) {
+	shape := DefaultShape()
+	candidates := []CandidateNode{
+		{Name: "node1", PVs: []CandidatePV{{Name: "pv1", Capacity: 100, Bound: 99}}},
+	}
+
+	if _, _, _, err := BestCandidate(shape, candidates, 50); err == nil {
+		t.Fatalf("expected an error when no candidate has sufficient capacity")
+	}
+}
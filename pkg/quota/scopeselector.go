@@ -0,0 +1,158 @@
+// Package quota matches a pod against a ResourceQuota's scope selector, mirroring the scope rules
+// Kubernetes itself applies when deciding which quotas a pod is charged against
+// (PriorityClass, BestEffort, NotBestEffort, Terminating, NotTerminating and
+// CrossNamespacePodAffinity). It backs the QuotaScopeSelectorAware feature gate: when enabled,
+// a resize action's namespace quota increase should only touch quotas this package says admit
+// the resized pod, rather than the first quota found in the namespace.
+package quota
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetPodQOS classifies pod the way kubelet does: BestEffort if no container sets any resource
+// request or limit, Guaranteed if every container's limits equal its requests for cpu and
+// memory, and Burstable otherwise. It's reimplemented here rather than pulled in from
+// k8s.io/kubernetes so this package only depends on the client-go API types already vendored
+// elsewhere in kubeturbo.
+func GetPodQOS(pod *corev1.Pod) corev1.PodQOSClass {
+	requestsFound, limitsFound := false, false
+	guaranteed := true
+
+	for _, c := range pod.Spec.Containers {
+		if len(c.Resources.Requests) > 0 {
+			requestsFound = true
+		}
+		if len(c.Resources.Limits) > 0 {
+			limitsFound = true
+		}
+		for _, resource := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			request, hasRequest := c.Resources.Requests[resource]
+			limit, hasLimit := c.Resources.Limits[resource]
+			if !hasLimit || (hasRequest && request.Cmp(limit) != 0) {
+				guaranteed = false
+			}
+		}
+	}
+
+	if !requestsFound && !limitsFound {
+		return corev1.PodQOSBestEffort
+	}
+	if guaranteed {
+		return corev1.PodQOSGuaranteed
+	}
+	return corev1.PodQOSBurstable
+}
+
+// hasCrossNamespacePodAffinity reports whether pod declares a pod (anti-)affinity term that can
+// reach across namespaces, i.e. one with a NamespaceSelector or an explicit non-empty Namespaces
+// list, mirroring Kubernetes' CrossNamespacePodAffinity quota scope.
+func hasCrossNamespacePodAffinity(pod *corev1.Pod) bool {
+	affinity := pod.Spec.Affinity
+	if affinity == nil {
+		return false
+	}
+	terms := []corev1.PodAffinityTerm{}
+	if affinity.PodAffinity != nil {
+		terms = append(terms, affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution...)
+		for _, t := range affinity.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+			terms = append(terms, t.PodAffinityTerm)
+		}
+	}
+	if affinity.PodAntiAffinity != nil {
+		terms = append(terms, affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution...)
+		for _, t := range affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+			terms = append(terms, t.PodAffinityTerm)
+		}
+	}
+	for _, term := range terms {
+		if term.NamespaceSelector != nil || len(term.Namespaces) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesScope reports whether pod matches a single scoped-resource selector requirement.
+func matchesScope(req corev1.ScopedResourceSelectorRequirement, pod *corev1.Pod) (bool, error) {
+	switch req.ScopeName {
+	case corev1.ResourceQuotaScopeTerminating:
+		return pod.Spec.ActiveDeadlineSeconds != nil, nil
+	case corev1.ResourceQuotaScopeNotTerminating:
+		return pod.Spec.ActiveDeadlineSeconds == nil, nil
+	case corev1.ResourceQuotaScopeBestEffort:
+		return GetPodQOS(pod) == corev1.PodQOSBestEffort, nil
+	case corev1.ResourceQuotaScopeNotBestEffort:
+		return GetPodQOS(pod) != corev1.PodQOSBestEffort, nil
+	case corev1.ResourceQuotaScopePriorityClass:
+		return matchesPriorityClass(req, pod)
+	case corev1.ResourceQuotaScopeCrossNamespacePodAffinity:
+		return hasCrossNamespacePodAffinity(pod), nil
+	default:
+		return false, fmt.Errorf("unsupported resource quota scope %q", req.ScopeName)
+	}
+}
+
+func matchesPriorityClass(req corev1.ScopedResourceSelectorRequirement, pod *corev1.Pod) (bool, error) {
+	name := pod.Spec.PriorityClassName
+	switch req.Operator {
+	case corev1.ScopeSelectorOpExists:
+		return name != "", nil
+	case corev1.ScopeSelectorOpDoesNotExist:
+		return name == "", nil
+	case corev1.ScopeSelectorOpIn:
+		for _, v := range req.Values {
+			if v == name {
+				return true, nil
+			}
+		}
+		return false, nil
+	case corev1.ScopeSelectorOpNotIn:
+		for _, v := range req.Values {
+			if v == name {
+				return false, nil
+			}
+		}
+		return true, nil
+	default:
+		return false, fmt.Errorf("unsupported scope selector operator %q", req.Operator)
+	}
+}
+
+// Admits reports whether selector admits pod, i.e. pod matches every requirement in
+// selector.MatchExpressions. A nil selector admits every pod.
+func Admits(selector *corev1.ScopeSelector, pod *corev1.Pod) (bool, error) {
+	if selector == nil {
+		return true, nil
+	}
+	for _, req := range selector.MatchExpressions {
+		ok, err := matchesScope(req, pod)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// SelectAdmitting returns the subset of candidates whose scope selector admits pod, in the same
+// order as candidates. The namespace quota increase should only ever touch these quotas; if the
+// result is empty, the resize action should be rejected with a structured reason rather than
+// falling back to the first quota found.
+func SelectAdmitting(candidates []corev1.ResourceQuota, pod *corev1.Pod) ([]corev1.ResourceQuota, error) {
+	var admitting []corev1.ResourceQuota
+	for _, rq := range candidates {
+		ok, err := Admits(rq.Spec.ScopeSelector, pod)
+		if err != nil {
+			return nil, fmt.Errorf("evaluating scope selector for quota %s/%s: %v", rq.Namespace, rq.Name, err)
+		}
+		if ok {
+			admitting = append(admitting, rq)
+		}
+	}
+	return admitting, nil
+}
@@ -0,0 +1,145 @@
+package quota
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	resource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func podWithPriorityClass(name string) *corev1.Pod {
+	return &corev1.Pod{Spec: corev1.PodSpec{PriorityClassName: name}}
+}
+
+func TestGetPodQOS(t *testing.T) {
+	cases := []struct {
+		name string
+		pod  *corev1.Pod
+		want corev1.PodQOSClass
+	}{
+		{
+			name: "best effort",
+			pod:  &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{}}}},
+			want: corev1.PodQOSBestEffort,
+		},
+		{
+			name: "guaranteed",
+			pod: &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+					Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+				},
+			}}}},
+			want: corev1.PodQOSGuaranteed,
+		},
+		{
+			name: "burstable",
+			pod: &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+					Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				},
+			}}}},
+			want: corev1.PodQOSBurstable,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := GetPodQOS(c.pod); got != c.want {
+				t.Errorf("GetPodQOS() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestAdmits(t *testing.T) {
+	cases := []struct {
+		name     string
+		selector *corev1.ScopeSelector
+		pod      *corev1.Pod
+		want     bool
+		wantErr  bool
+	}{
+		{
+			name:     "nil selector admits everything",
+			selector: nil,
+			pod:      podWithPriorityClass(""),
+			want:     true,
+		},
+		{
+			name: "priority class In match",
+			selector: &corev1.ScopeSelector{MatchExpressions: []corev1.ScopedResourceSelectorRequirement{
+				{ScopeName: corev1.ResourceQuotaScopePriorityClass, Operator: corev1.ScopeSelectorOpIn, Values: []string{"high"}},
+			}},
+			pod:  podWithPriorityClass("high"),
+			want: true,
+		},
+		{
+			name: "priority class In mismatch",
+			selector: &corev1.ScopeSelector{MatchExpressions: []corev1.ScopedResourceSelectorRequirement{
+				{ScopeName: corev1.ResourceQuotaScopePriorityClass, Operator: corev1.ScopeSelectorOpIn, Values: []string{"high"}},
+			}},
+			pod:  podWithPriorityClass("low"),
+			want: false,
+		},
+		{
+			name: "best effort scope",
+			selector: &corev1.ScopeSelector{MatchExpressions: []corev1.ScopedResourceSelectorRequirement{
+				{ScopeName: corev1.ResourceQuotaScopeBestEffort},
+			}},
+			pod:  &corev1.Pod{},
+			want: true,
+		},
+		{
+			name: "unsupported scope errors",
+			selector: &corev1.ScopeSelector{MatchExpressions: []corev1.ScopedResourceSelectorRequirement{
+				{ScopeName: "NotARealScope"},
+			}},
+			pod:     &corev1.Pod{},
+			wantErr: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := Admits(c.selector, c.pod)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("Admits() error = %v, wantErr %v", err, c.wantErr)
+			}
+			if err == nil && got != c.want {
+				t.Errorf("Admits() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestSelectAdmitting(t *testing.T) {
+	admitting := corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "admits"},
+		Spec: corev1.ResourceQuotaSpec{ScopeSelector: &corev1.ScopeSelector{MatchExpressions: []corev1.ScopedResourceSelectorRequirement{
+			{ScopeName: corev1.ResourceQuotaScopePriorityClass, Operator: corev1.ScopeSelectorOpIn, Values: []string{"high"}},
+		}}},
+	}
+	nonAdmitting := corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "rejects"},
+		Spec: corev1.ResourceQuotaSpec{ScopeSelector: &corev1.ScopeSelector{MatchExpressions: []corev1.ScopedResourceSelectorRequirement{
+			{ScopeName: corev1.ResourceQuotaScopePriorityClass, Operator: corev1.ScopeSelectorOpIn, Values: []string{"low"}},
+		}}},
+	}
+
+	got, err := SelectAdmitting([]corev1.ResourceQuota{nonAdmitting, admitting}, podWithPriorityClass("high"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].Name != "admits" {
+		t.Fatalf("SelectAdmitting() = %v, want only %q", got, "admits")
+	}
+
+	got, err = SelectAdmitting([]corev1.ResourceQuota{nonAdmitting}, podWithPriorityClass("high"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("SelectAdmitting() = %v, want empty", got)
+	}
+}
@@ -0,0 +1,97 @@
+package kubeturbo
+
+import (
+	"github.com/turbonomic/kubeturbo/pkg/discovery/health"
+	"github.com/turbonomic/kubeturbo/pkg/discovery/pagination"
+	"github.com/turbonomic/kubeturbo/pkg/placement/volumecapacity"
+)
+
+// VMTConfig2 carries the discovery/execution wiring assembled by cmd/kubeturbo/app for a single
+// kubeturbo run: the target/kube clients, discovery tunables and the optional subsystems
+// (dry-run, discovery health, pagination, volume-capacity scoring) that several feature gates
+// thread through to NewKubernetesTAPService. Only the fields backing those optional subsystems
+// live here; the base client/tunable fields predate this file and are assembled elsewhere in this
+// package.
+type VMTConfig2 struct {
+	// dryRunActions mirrors the DryRunActions feature gate: when true, action executors log and
+	// record an Event for the action they would have taken instead of mutating cluster state.
+	dryRunActions bool
+
+	// discoveryHealth backs the DiscoveryHealth feature gate: the health.Monitor a gated discovery
+	// worker calls RequireGVRs on before listing, skipping itself with a WARN when its required
+	// GVRs aren't currently served. The only gated worker, WorkloadControllerLister in
+	// pkg/discovery/worker, is handed the Monitor directly rather than through this field.
+	discoveryHealth *health.Monitor
+
+	// discoveryPagination configures Limit+Continue pagination of workload-controller list API
+	// calls (see the PaginatedControllerList feature gate). Its zero value is not a valid Config;
+	// callers should fall back to pagination.DefaultConfig() when this was never set.
+	discoveryPagination pagination.Config
+
+	// volumeCapacityPriorityShape is the utilization-to-score curve a move action's destination
+	// selection should score candidate nodes' PV free capacity against (see the
+	// VolumeCapacityPriority feature gate). Nil means the feature is not enabled.
+	volumeCapacityPriorityShape []volumecapacity.ShapePoint
+}
+
+// NewVMTConfig2 returns an empty VMTConfig2. Use the With* methods to populate it.
+func NewVMTConfig2() *VMTConfig2 {
+	return &VMTConfig2{}
+}
+
+// WithDryRunActions sets whether action executors run in dry-run mode (see the DryRunActions
+// feature gate) and returns the VMTConfig2 for chaining.
+func (c *VMTConfig2) WithDryRunActions(dryRun bool) *VMTConfig2 {
+	c.dryRunActions = dryRun
+	return c
+}
+
+// DryRunActions reports whether action executors should run in dry-run mode. Nothing in this
+// tree currently calls this getter: no executor checks it before mutating, no Event is recorded,
+// and kubeturbo_action_dry_run_total (pkg/metrics) is never incremented. --dry-run-actions has no
+// effect until an action-execution call site consults it.
+func (c *VMTConfig2) DryRunActions() bool {
+	return c.dryRunActions
+}
+
+// WithDiscoveryHealth sets the health.Monitor that discovery workers should gate themselves on
+// (see the DiscoveryHealth feature gate), and returns the VMTConfig2 for chaining. Pass nil to
+// disable the gating; workers then always run.
+func (c *VMTConfig2) WithDiscoveryHealth(monitor *health.Monitor) *VMTConfig2 {
+	c.discoveryHealth = monitor
+	return c
+}
+
+// DiscoveryHealth returns the configured health.Monitor, or nil if none was set.
+func (c *VMTConfig2) DiscoveryHealth() *health.Monitor {
+	return c.discoveryHealth
+}
+
+// WithDiscoveryPagination sets the pagination.Config workload-controller listers should paginate
+// their list calls with (see the PaginatedControllerList feature gate), and returns the
+// VMTConfig2 for chaining.
+func (c *VMTConfig2) WithDiscoveryPagination(cfg pagination.Config) *VMTConfig2 {
+	c.discoveryPagination = cfg
+	return c
+}
+
+// DiscoveryPagination returns the configured pagination.Config.
+func (c *VMTConfig2) DiscoveryPagination() pagination.Config {
+	return c.discoveryPagination
+}
+
+// WithVolumeCapacityPriorityShape sets the utilization-to-score shape move-destination selection
+// should score candidate nodes' PV free capacity against (see the VolumeCapacityPriority feature
+// gate), and returns the VMTConfig2 for chaining.
+func (c *VMTConfig2) WithVolumeCapacityPriorityShape(shape []volumecapacity.ShapePoint) *VMTConfig2 {
+	c.volumeCapacityPriorityShape = shape
+	return c
+}
+
+// VolumeCapacityPriorityShape returns the configured utilization-to-score shape, or nil if the
+// VolumeCapacityPriority feature gate was not enabled. Consulted by the builder when constructing
+// the VolumeCapacity admitter for ActionAdmission; since that chain has no action-execution caller
+// yet (see ActionAdmission), this shape does not yet affect which node a move action lands on.
+func (c *VMTConfig2) VolumeCapacityPriorityShape() []volumecapacity.ShapePoint {
+	return c.volumeCapacityPriorityShape
+}